@@ -0,0 +1,13 @@
+// Package mathx contains integer math helpers shared between packages.
+package mathx
+
+// FloorDiv divides a by b, rounding towards negative infinity rather than
+// towards zero, so that negative offsets map to the correct period.
+func FloorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+
+	return q
+}
@@ -0,0 +1,23 @@
+package mathx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloorDiv(t *testing.T) {
+	for _, tt := range []struct {
+		a, b, want int
+	}{
+		{7, 2, 3},
+		{6, 2, 3},
+		{-7, 2, -4},
+		{-6, 2, -3},
+		{7, -2, -4},
+		{-7, -2, 3},
+		{0, 5, 0},
+	} {
+		assert.Equal(t, tt.want, FloorDiv(tt.a, tt.b), "%d / %d", tt.a, tt.b)
+	}
+}
@@ -1,10 +1,12 @@
 package cli
 
 import (
-	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sync"
 
@@ -32,23 +34,63 @@ const (
 	FormatUnknown Format = ""
 	FormatJSON    Format = "json"
 	FormatCSV     Format = "csv"
+	FormatTSV     Format = "tsv"
+	FormatExcel   Format = "excel"
+	FormatCSVGzip Format = "csv.gz"
 )
 
+// A SplitFormatter is a Formatter that can split its output across multiple files.
+type SplitFormatter interface {
+	Formatter
+	WriteSplit(path string, compact bool, val any, splitOpts csv2.SplitOptions) error
+}
+
 // FormattedOutput renders output using formatting
 type FormattedOutput struct {
 	Output
-	Format Format `help:"the format to use for output" default:"json"`
+	Format   Format `help:"the format to use for output" default:"json"`
+	MaxRows  int    `name:"max-rows" help:"split output into files of at most this many rows"`
+	MaxBytes int64  `name:"max-bytes" help:"split output into files of at most this many bytes"`
 }
 
-// WriteFormatted writes the given output according to the format.
+// WriteFormatted writes the given output according to the format. If MaxRows
+// or MaxBytes is set, the output is split into numbered files based on the
+// output location, which the format must support.
 func (cmd *FormattedOutput) WriteFormatted(val any) error {
 	formatter, ok := LookupFormatter(cmd.Format)
 	if !ok {
 		return fmt.Errorf("unknown format '%s'", cmd.Format)
 	}
 
-	return cmd.WriteOutput(func(w io.Writer) error {
-		return formatter.WriteFormatted(w, cmd.Compact, val)
+	if cmd.MaxRows == 0 && cmd.MaxBytes == 0 {
+		return cmd.WriteOutput(func(w io.Writer) error {
+			return formatter.WriteFormatted(w, cmd.Compact, val)
+		})
+	}
+
+	sf, ok := formatter.(SplitFormatter)
+	if !ok {
+		return fmt.Errorf("format '%s' does not support splitting output", cmd.Format)
+	}
+
+	if cmd.Output.Output == "" || cmd.Output.Output == "--" {
+		return fmt.Errorf("an output location is required to split output")
+	}
+
+	if cmd.Output.Output == OutputToTemp {
+		// Split into a temp directory, replacing the output with the base
+		// path of the files
+		dir, err := os.MkdirTemp("", "base-cmp-test")
+		if err != nil {
+			return err
+		}
+
+		cmd.Output.Output = filepath.Join(dir, "output")
+	}
+
+	return sf.WriteSplit(cmd.Output.Output, cmd.Compact, val, csv2.SplitOptions{
+		MaxRows:  cmd.MaxRows,
+		MaxBytes: cmd.MaxBytes,
 	})
 }
 
@@ -80,21 +122,81 @@ func init() {
 		return enc.Encode(val)
 	}))
 
-	RegisterFormatter(FormatCSV, FormatterFn(func(w io.Writer, compact bool, val any) error {
-		m, err := csv2.NewMarshaller(reflect.TypeOf(val))
-		if err != nil {
-			return err
-		}
+	RegisterFormatter(FormatCSV, CSVFormatter(csv2.WriterOptions{}))
+	RegisterFormatter(FormatTSV, CSVFormatter(csv2.WriterOptions{Comma: '\t'}))
+	RegisterFormatter(FormatExcel, CSVFormatter(csv2.WriterOptions{
+		UseCRLF:     true,
+		AlwaysQuote: true,
+		BOM:         true,
+	}))
+	RegisterFormatter(FormatCSVGzip, GzipCSVFormatter(csv2.WriterOptions{}))
+}
 
-		csvw := csv.NewWriter(w)
-		defer csvw.Flush()
+// CSVFormatter returns a Formatter that writes CSV using the given writer options,
+// omitting the headers in compact mode. The Formatter is also a SplitFormatter.
+func CSVFormatter(opts csv2.WriterOptions) Formatter {
+	return &csvFormatter{opts: opts}
+}
 
-		if !compact {
-			if err := csvw.Write(m.Headers()); err != nil {
-				return fmt.Errorf("unable to write header: %w", err)
-			}
+// GzipCSVFormatter returns a Formatter that writes gzip-compressed CSV using the
+// given writer options, omitting the headers in compact mode. The Formatter is
+// also a SplitFormatter.
+func GzipCSVFormatter(opts csv2.WriterOptions) Formatter {
+	return &csvFormatter{opts: opts, gzip: true}
+}
+
+type csvFormatter struct {
+	opts csv2.WriterOptions
+	gzip bool
+}
+
+func (f *csvFormatter) WriteFormatted(w io.Writer, compact bool, val any) error {
+	m, err := csv2.NewMarshaller(reflect.TypeOf(val))
+	if err != nil {
+		return err
+	}
+
+	if f.gzip {
+		// Close even if encoding fails, so the gzip stream is complete
+		csvw := csv2.NewGzipWriter(w, f.opts)
+		err := f.encode(csvw, m, compact, val)
+		return errors.Join(err, csvw.Close())
+	}
+
+	// Flush even if encoding fails, so the rows already written are not lost
+	csvw := csv2.NewWriter(w, f.opts)
+	err = f.encode(csvw, m, compact, val)
+	csvw.Flush()
+	return errors.Join(err, csvw.Error())
+}
+
+func (f *csvFormatter) WriteSplit(path string, compact bool, val any, splitOpts csv2.SplitOptions) error {
+	m, err := csv2.NewMarshaller(reflect.TypeOf(val))
+	if err != nil {
+		return err
+	}
+
+	var headers []string
+	if !compact {
+		headers = m.Headers()
+	}
+
+	splitOpts.Gzip = f.gzip
+	csvw := csv2.NewSplitWriter(csv2.CreateSplitFiles(path), headers, f.opts, splitOpts)
+	err = m.Encode(csvw, val, "")
+	return errors.Join(err, csvw.Close())
+}
+
+func (f *csvFormatter) encode(w csv2.RowWriter, m csv2.Marshaller, compact bool, val any) error {
+	if !compact {
+		if err := w.Write(m.Headers()); err != nil {
+			return fmt.Errorf("unable to write header: %w", err)
 		}
+	}
 
-		return m.Encode(csvw, val, "")
-	}))
+	return m.Encode(w, val, "")
 }
+
+var (
+	_ SplitFormatter = &csvFormatter{}
+)
@@ -1,11 +1,40 @@
 package cli
 
 import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	csv2 "github.com/mmihic/golib/src/pkg/encoding/csv"
 )
 
+type book struct {
+	Title  string `json:"title"`
+	Author string `json:"author"`
+}
+
+var books = []book{
+	{
+		Title:  "This is my title",
+		Author: "joe@banana.com",
+	},
+	{
+		Title:  "This is my other title",
+		Author: "jane@banana.com",
+	},
+	{
+		Title:  "This is my third title",
+		Author: "jackie@banana.com",
+	},
+}
+
 func TestFormattedOutput_CSV(t *testing.T) {
 	out := &FormattedOutput{
 		Format: FormatCSV,
@@ -36,6 +65,177 @@ func TestFormattedOutput_CSV(t *testing.T) {
 	assertFileMatches(t, &out.Output, "testdata/formatted.csv")
 }
 
+func TestFormattedOutput_TSV(t *testing.T) {
+	out := &FormattedOutput{
+		Format: FormatTSV,
+		Output: Output{
+			Output: OutputToTemp,
+		},
+	}
+
+	err := out.WriteFormatted([]struct {
+		Title  string `json:"title"`
+		Author string `json:"author"`
+	}{
+		{
+			Title:  "This is my title",
+			Author: "joe@banana.com",
+		},
+		{
+			Title:  "This is my other title",
+			Author: "jane@banana.com",
+		},
+		{
+			Title:  "This is my third title",
+			Author: "jackie@banana.com",
+		},
+	})
+
+	require.NoError(t, err)
+	assertFileMatches(t, &out.Output, "testdata/formatted.tsv")
+}
+
+func TestFormattedOutput_CSVGzip(t *testing.T) {
+	out := &FormattedOutput{
+		Format: FormatCSVGzip,
+		Output: Output{
+			Output: OutputToTemp,
+		},
+	}
+
+	require.NoError(t, out.WriteFormatted(books))
+
+	data, err := os.ReadFile(out.Output.Output)
+	require.NoError(t, err)
+
+	assert.Equal(t, "Title,Author\n"+
+		"This is my title,joe@banana.com\n"+
+		"This is my other title,jane@banana.com\n"+
+		"This is my third title,jackie@banana.com\n", gunzip(t, data))
+}
+
+type failingTitle string
+
+func (t failingTitle) MarshalText() ([]byte, error) {
+	if t == "" {
+		return nil, errors.New("missing title")
+	}
+
+	return []byte(t), nil
+}
+
+func TestFormattedOutput_CSVGzipEncodeError(t *testing.T) {
+	var buf bytes.Buffer
+	err := GzipCSVFormatter(csv2.WriterOptions{}).WriteFormatted(&buf, false, []struct {
+		Title failingTitle
+	}{
+		{Title: "This is my title"},
+		{Title: ""},
+	})
+	require.ErrorContains(t, err, "missing title")
+
+	// The gzip stream is still closed, holding the rows written before the error
+	assert.Equal(t, "Title\nThis is my title\n", gunzip(t, buf.Bytes()))
+}
+
+func TestFormattedOutput_CSVEncodeError(t *testing.T) {
+	var buf bytes.Buffer
+	err := CSVFormatter(csv2.WriterOptions{}).WriteFormatted(&buf, false, []struct {
+		Title failingTitle
+	}{
+		{Title: "This is my title"},
+		{Title: ""},
+	})
+	require.ErrorContains(t, err, "missing title")
+
+	// The rows written before the error are flushed
+	assert.Equal(t, "Title\nThis is my title\n", buf.String())
+}
+
+func TestFormattedOutput_Split(t *testing.T) {
+	dir := t.TempDir()
+	out := &FormattedOutput{
+		Format: FormatCSV,
+		Output: Output{
+			Output: filepath.Join(dir, "books.csv"),
+		},
+		MaxRows: 2,
+	}
+
+	require.NoError(t, out.WriteFormatted(books))
+
+	for _, tt := range []struct {
+		filename string
+		expected string
+	}{
+		{
+			"books-0001.csv",
+			"Title,Author\nThis is my title,joe@banana.com\nThis is my other title,jane@banana.com\n",
+		},
+		{
+			"books-0002.csv",
+			"Title,Author\nThis is my third title,jackie@banana.com\n",
+		},
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, tt.filename))
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, string(data), tt.filename)
+	}
+}
+
+func TestFormattedOutput_SplitToTemp(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	out := &FormattedOutput{
+		Format: FormatCSV,
+		Output: Output{
+			Output: OutputToTemp,
+		},
+		MaxRows: 2,
+	}
+
+	require.NoError(t, out.WriteFormatted(books))
+	defer func() {
+		_ = os.RemoveAll(filepath.Dir(out.Output.Output))
+	}()
+
+	// Files are written next to the temp output, not the working directory
+	assert.NotEqual(t, wd, filepath.Dir(out.Output.Output))
+	_, err = os.Stat(filepath.Join(wd, OutputToTemp+"-0001"))
+	assert.True(t, os.IsNotExist(err))
+
+	for _, filename := range []string{"output-0001", "output-0002"} {
+		_, err := os.Stat(filepath.Join(filepath.Dir(out.Output.Output), filename))
+		assert.NoError(t, err, filename)
+	}
+}
+
+func TestFormattedOutput_SplitErrors(t *testing.T) {
+	out := &FormattedOutput{
+		Format:  FormatJSON,
+		MaxRows: 2,
+	}
+
+	err := out.WriteFormatted(books)
+	require.Error(t, err)
+	assert.Equal(t, "format 'json' does not support splitting output", err.Error())
+
+	out.Format = FormatCSV
+	err = out.WriteFormatted(books)
+	require.Error(t, err)
+	assert.Equal(t, "an output location is required to split output", err.Error())
+}
+
+func gunzip(t *testing.T, data []byte) string {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
 func TestFormattedOutput_JSON(t *testing.T) {
 	out := &FormattedOutput{
 		Format: FormatJSON,
@@ -2,6 +2,8 @@
 // that uses generics. The list is not go-routine safe.
 package list
 
+import "sync"
+
 // Element is an element of a doubly linked list
 type Element[V any] struct {
 	Value      V
@@ -9,7 +11,19 @@ type Element[V any] struct {
 	next, prev *Element[V]
 }
 
+// unlink removes the element from its position in the list, updating the
+// front and back of the list if needed.
 func (e *Element[V]) unlink() {
+	if e.list != nil {
+		if e.list.front == e {
+			e.list.front = e.next
+		}
+
+		if e.list.back == e {
+			e.list.back = e.prev
+		}
+	}
+
 	if e.next != nil {
 		e.next.prev = e.prev
 	}
@@ -17,6 +31,14 @@ func (e *Element[V]) unlink() {
 	if e.prev != nil {
 		e.prev.next = e.next
 	}
+
+	e.next, e.prev = nil, nil
+}
+
+// detach clears the element's links, so that it is no longer
+// considered part of any list.
+func (e *Element[V]) detach() {
+	e.list, e.next, e.prev = nil, nil, nil
 }
 
 // Next returns the next list element or nil.
@@ -33,11 +55,122 @@ func (e *Element[V]) Prev() *Element[V] {
 type List[V any] struct {
 	numElements int
 	front, back *Element[V]
+	pool        *sync.Pool
+	maxLen      int
+	onOverflow  func(v V)
+}
+
+// An Option configures a List.
+type Option[V any] func(l *List[V])
+
+// WithElementPool recycles the elements removed from the list through a
+// sync.Pool, rather than allocating a new element for every insertion. This
+// reduces GC pressure for lists with a lot of churn, such as caches. Since
+// removed elements are reused, callers must not hold on to an element once
+// it has been removed from the list.
+func WithElementPool[V any]() Option[V] {
+	return func(l *List[V]) {
+		l.pool = &sync.Pool{
+			New: func() any {
+				return &Element[V]{}
+			},
+		}
+	}
 }
 
 // New returns a new empty list.
-func New[V any]() *List[V] {
-	return &List[V]{}
+func New[V any](opts ...Option[V]) *List[V] {
+	l := &List[V]{}
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	return l
+}
+
+// NewBounded returns a new empty list that holds at most maxLen elements.
+// When an insertion takes the list past maxLen, an element is evicted from the
+// opposite end of the list to the new element (from the back for elements
+// inserted in the middle), and its value is passed to onOverflow if not nil.
+// A list that is pushed to the front and evicts from the back is the basis
+// of an LRU cache.
+func NewBounded[V any](maxLen int, onOverflow func(v V), opts ...Option[V]) *List[V] {
+	l := New(opts...)
+	l.maxLen, l.onOverflow = maxLen, onOverflow
+	return l
+}
+
+// trim evicts an element if list l has grown past its bound after
+// inserting element e.
+func (l *List[V]) trim(e *Element[V]) {
+	if l.maxLen <= 0 || l.numElements <= l.maxLen {
+		return
+	}
+
+	evicted := l.back
+	if e == l.back {
+		evicted = l.front
+	}
+
+	v := l.Remove(evicted)
+	if l.onOverflow != nil {
+		l.onOverflow(v)
+	}
+}
+
+// newElement returns an element of list l holding value v, reusing
+// a pooled element if available.
+func (l *List[V]) newElement(v V) *Element[V] {
+	var e *Element[V]
+	if l.pool != nil {
+		e = l.pool.Get().(*Element[V])
+	} else {
+		e = &Element[V]{}
+	}
+
+	e.Value, e.list = v, l
+	return e
+}
+
+// release detaches an element that has been removed from list l,
+// returning it to the pool if there is one.
+func (l *List[V]) release(e *Element[V]) {
+	e.detach()
+	if l.pool != nil {
+		var zero V
+		e.Value = zero
+		l.pool.Put(e)
+	}
+}
+
+// FromSlice returns a new list holding the given values, in order.
+func FromSlice[V any](values []V) *List[V] {
+	l := New[V]()
+	for _, v := range values {
+		l.PushBack(v)
+	}
+
+	return l
+}
+
+// Init initializes or clears list l.
+func (l *List[V]) Init() *List[V] {
+	l.Clear()
+	return l
+}
+
+// Clear removes all elements from list l. The removed elements are detached
+// from the list, so they can no longer be used to move or remove elements,
+// and are returned to the element pool if the list has one. The complexity
+// is O(n).
+func (l *List[V]) Clear() {
+	for e := l.front; e != nil; {
+		next := e.next
+		l.release(e)
+		e = next
+	}
+
+	l.front, l.back, l.numElements = nil, nil, 0
 }
 
 // Front returns the first element of list l or nil if the list is empty.
@@ -62,11 +195,6 @@ func (l *List[V]) MoveAfter(e, mark *Element[V]) {
 }
 
 func (l *List[V]) moveAfterInternal(e *Element[V], mark *Element[V]) {
-	if mark == l.back {
-		l.MoveToBack(e)
-		return
-	}
-
 	e.unlink()
 
 	// [mark] <-> [mark.next] becomes
@@ -74,6 +202,8 @@ func (l *List[V]) moveAfterInternal(e *Element[V], mark *Element[V]) {
 	// [e] <- [mark.next], [mark] -> [mark.next]
 	if mark.next != nil {
 		mark.next.prev = e
+	} else {
+		l.back = e
 	}
 
 	// [e] <-> [mark.next], [mark] -> [mark.next]
@@ -98,17 +228,14 @@ func (l *List[V]) MoveBefore(e, mark *Element[V]) {
 }
 
 func (l *List[V]) moveBeforeInternal(e *Element[V], mark *Element[V]) {
-	if mark == l.front {
-		l.MoveToFront(e)
-		return
-	}
-
 	e.unlink()
 
 	// [mark.prev] <-> [mark] becomes
 	// [mark.prev] -> [e], [mark.prev] <- [mark]
 	if mark.prev != nil {
 		mark.prev.next = e
+	} else {
+		l.front = e
 	}
 
 	// [mark.prev] <-> [e], [mark.prev] <- [mark]
@@ -169,6 +296,59 @@ func (l *List[V]) moveToFrontInternal(e *Element[V]) {
 	}
 }
 
+// MoveUp moves element e one position towards the front of list l. If e is
+// not an element of l, or is already at the front, the list is not modified.
+// The element must not be nil.
+func (l *List[V]) MoveUp(e *Element[V]) {
+	if e.list != l || e.prev == nil {
+		return
+	}
+
+	l.moveBeforeInternal(e, e.prev)
+}
+
+// MoveDown moves element e one position towards the back of list l. If e is
+// not an element of l, or is already at the back, the list is not modified.
+// The element must not be nil.
+func (l *List[V]) MoveDown(e *Element[V]) {
+	if e.list != l || e.next == nil {
+		return
+	}
+
+	l.moveAfterInternal(e, e.next)
+}
+
+// Swap swaps the positions of elements a and b in list l. If a or b is not
+// an element of l, or a == b, the list is not modified. The elements must
+// not be nil.
+func (l *List[V]) Swap(a, b *Element[V]) {
+	if a.list != l || b.list != l || a == b {
+		return
+	}
+
+	// If the elements are adjacent, make sure a is the one in front
+	if a.prev == b {
+		a, b = b, a
+	}
+
+	prev := a.prev
+	l.moveAfterInternal(a, b)
+	if prev == nil {
+		l.moveToFrontInternal(b)
+	} else {
+		l.moveAfterInternal(b, prev)
+	}
+}
+
+// Reverse reverses the order of the elements in list l. The complexity is O(n).
+func (l *List[V]) Reverse() {
+	for e := l.front; e != nil; e = e.prev {
+		e.next, e.prev = e.prev, e.next
+	}
+
+	l.front, l.back = l.back, l.front
+}
+
 // Remove removes e from l if e is an element of list l. It returns the element
 // value e.Value. The element must not be nil.
 func (l *List[V]) Remove(e *Element[V]) V {
@@ -177,17 +357,12 @@ func (l *List[V]) Remove(e *Element[V]) V {
 		return noop
 	}
 
-	if e == l.front {
-		l.front = l.front.next
-	}
-
-	if e == l.back {
-		l.back = l.back.prev
-	}
-
 	e.unlink()
 	l.numElements--
-	return e.Value
+
+	v := e.Value
+	l.release(e)
+	return v
 }
 
 // Len returns the number of elements of list l. The complexity is O(1).
@@ -197,31 +372,29 @@ func (l *List[V]) Len() int {
 
 // PushFront inserts a new element e with value v at the front of list l and returns e.
 func (l *List[V]) PushFront(v V) *Element[V] {
-	e := &Element[V]{
-		Value: v,
-		list:  l,
-	}
+	e := l.newElement(v)
 
 	l.numElements++
 	l.moveToFrontInternal(e)
 	if l.back == nil {
 		l.back = e
 	}
+
+	l.trim(e)
 	return e
 }
 
 // PushBack inserts a new element e with value v at the back of list l and returns e.
 func (l *List[V]) PushBack(v V) *Element[V] {
-	e := &Element[V]{
-		Value: v,
-		list:  l,
-	}
+	e := l.newElement(v)
 
 	l.numElements++
 	l.moveToBackInternal(e)
 	if l.front == nil {
 		l.front = e
 	}
+
+	l.trim(e)
 	return e
 }
 
@@ -231,10 +404,7 @@ func (l *List[V]) InsertBefore(v V, mark *Element[V]) *Element[V] {
 		return nil
 	}
 
-	e := &Element[V]{
-		Value: v,
-		list:  l,
-	}
+	e := l.newElement(v)
 
 	l.numElements++
 	if l.numElements == 0 {
@@ -244,6 +414,7 @@ func (l *List[V]) InsertBefore(v V, mark *Element[V]) *Element[V] {
 	}
 
 	l.moveBeforeInternal(e, mark)
+	l.trim(e)
 	return e
 }
 
@@ -253,10 +424,7 @@ func (l *List[V]) InsertAfter(v V, mark *Element[V]) *Element[V] {
 		return nil
 	}
 
-	e := &Element[V]{
-		Value: v,
-		list:  l,
-	}
+	e := l.newElement(v)
 
 	l.numElements++
 	if l.numElements == 0 {
@@ -266,5 +434,111 @@ func (l *List[V]) InsertAfter(v V, mark *Element[V]) *Element[V] {
 	}
 
 	l.moveAfterInternal(e, mark)
+	l.trim(e)
 	return e
 }
+
+// Find returns the first element of list l whose value matches the predicate,
+// or nil if there is no such element.
+func (l *List[V]) Find(pred func(v V) bool) *Element[V] {
+	for e := l.front; e != nil; e = e.next {
+		if pred(e.Value) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// RemoveIf removes all elements of list l whose value matches the predicate,
+// returning the number of elements removed.
+func (l *List[V]) RemoveIf(pred func(v V) bool) int {
+	var removed int
+	for e := l.front; e != nil; {
+		next := e.next
+		if pred(e.Value) {
+			l.Remove(e)
+			removed++
+		}
+		e = next
+	}
+
+	return removed
+}
+
+// Sort sorts the elements of list l in place using the given less function.
+// The sort is stable, and relinks the existing elements rather than copying
+// values, so elements remain valid after the sort. The complexity is O(n log n).
+func (l *List[V]) Sort(less func(a, b V) bool) {
+	if l.numElements < 2 {
+		return
+	}
+
+	front := mergeSort(l.front, less)
+
+	// The merge only maintains the next links, so fix up the prev links
+	var prev *Element[V]
+	for e := front; e != nil; e = e.next {
+		e.prev = prev
+		prev = e
+	}
+
+	l.front, l.back = front, prev
+}
+
+// mergeSort sorts the chain of elements starting at front, following
+// only the next links, and returns the new front of the chain.
+func mergeSort[V any](front *Element[V], less func(a, b V) bool) *Element[V] {
+	if front == nil || front.next == nil {
+		return front
+	}
+
+	// Find the middle of the chain and split it in two
+	slow, fast := front, front.next
+	for fast != nil && fast.next != nil {
+		slow, fast = slow.next, fast.next.next
+	}
+
+	second := slow.next
+	slow.next = nil
+
+	return merge(mergeSort(front, less), mergeSort(second, less), less)
+}
+
+// merge merges two sorted chains of elements, preferring elements from
+// a when values are equal so that the sort is stable.
+func merge[V any](a, b *Element[V], less func(a, b V) bool) *Element[V] {
+	var head Element[V]
+	tail := &head
+	for a != nil && b != nil {
+		if less(b.Value, a.Value) {
+			tail.next, b = b, b.next
+		} else {
+			tail.next, a = a, a.next
+		}
+		tail = tail.next
+	}
+
+	if a != nil {
+		tail.next = a
+	} else {
+		tail.next = b
+	}
+
+	return head.next
+}
+
+// ToSlice returns the values of list l as a slice, from front to back.
+func (l *List[V]) ToSlice() []V {
+	return l.AppendTo(make([]V, 0, l.numElements))
+}
+
+// AppendTo appends the values of list l to dst, from front to back,
+// and returns the extended slice.
+func (l *List[V]) AppendTo(dst []V) []V {
+	for e := l.front; e != nil; e = e.next {
+		dst = append(dst, e.Value)
+	}
+
+	return dst
+}
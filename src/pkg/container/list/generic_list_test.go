@@ -177,6 +177,322 @@ func TestList_Remove(t *testing.T) {
 	requireListEquals(t, l, []string{"bar", "quark"})
 }
 
+func TestList_RemoveTwice(t *testing.T) {
+	l := New[string]()
+	l.PushBack("foo")
+	e := l.PushBack("bar")
+
+	require.Equal(t, "bar", l.Remove(e))
+	require.Nil(t, e.Next())
+	require.Nil(t, e.Prev())
+
+	// The element is no longer in the list, so removing it again does nothing
+	require.Equal(t, "", l.Remove(e))
+	require.Equal(t, 1, l.Len())
+	requireListEquals(t, l, []string{"foo"})
+}
+
+func TestList_Clear(t *testing.T) {
+	l := New[string]()
+
+	var elements []*Element[string]
+	for _, val := range []string{"foo", "bar", "zed"} {
+		elements = append(elements, l.PushBack(val))
+	}
+
+	l.Clear()
+	require.Equal(t, 0, l.Len())
+	requireListEquals(t, l, []string{})
+
+	for _, e := range elements {
+		require.Nil(t, e.Next())
+		require.Nil(t, e.Prev())
+
+		// Detached elements can no longer be used with the list
+		l.Remove(e)
+		l.MoveToFront(e)
+		require.Equal(t, 0, l.Len())
+	}
+
+	// The list can be reused after being cleared
+	l.PushBack("mork")
+	l.PushFront("ork")
+	requireListEquals(t, l, []string{"ork", "mork"})
+
+	require.Same(t, l, l.Init())
+	require.Equal(t, 0, l.Len())
+	requireListEquals(t, l, []string{})
+}
+
+func TestList_Find(t *testing.T) {
+	l := New[string]()
+	l.PushBack("foo")
+	bar := l.PushBack("bar")
+	l.PushBack("baz")
+
+	require.Same(t, bar, l.Find(func(v string) bool { return v[0] == 'b' }))
+	require.Nil(t, l.Find(func(v string) bool { return v == "zed" }))
+	require.Nil(t, New[string]().Find(func(string) bool { return true }))
+}
+
+func TestList_RemoveIf(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		values   []int
+		pred     func(int) bool
+		removed  int
+		expected []int
+	}{
+		{"none", []int{1, 2, 3}, func(int) bool { return false }, 0, []int{1, 2, 3}},
+		{"all", []int{1, 2, 3}, func(int) bool { return true }, 3, []int{}},
+		{"evens", []int{1, 2, 3, 4, 6}, func(v int) bool { return v%2 == 0 }, 3, []int{1, 3}},
+		{"ends", []int{1, 2, 3, 4, 5}, func(v int) bool { return v == 1 || v == 5 }, 2, []int{2, 3, 4}},
+		{"empty", nil, func(int) bool { return true }, 0, []int{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New[int]()
+			for _, v := range tt.values {
+				l.PushBack(v)
+			}
+
+			require.Equal(t, tt.removed, l.RemoveIf(tt.pred))
+			require.Equal(t, len(tt.expected), l.Len())
+			requireListEquals(t, l, tt.expected)
+		})
+	}
+}
+
+func TestList_Sort(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		values   []int
+		expected []int
+	}{
+		{"empty", nil, []int{}},
+		{"single", []int{1}, []int{1}},
+		{"sorted", []int{1, 2, 3, 4}, []int{1, 2, 3, 4}},
+		{"reversed", []int{5, 4, 3, 2, 1}, []int{1, 2, 3, 4, 5}},
+		{"duplicates", []int{3, 1, 2, 3, 1, 7, 0}, []int{0, 1, 1, 2, 3, 3, 7}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New[int]()
+			for _, v := range tt.values {
+				l.PushBack(v)
+			}
+
+			l.Sort(func(a, b int) bool { return a < b })
+			require.Equal(t, len(tt.expected), l.Len())
+			requireListEquals(t, l, tt.expected)
+		})
+	}
+}
+
+func TestList_SortIsStable(t *testing.T) {
+	type item struct {
+		priority int
+		name     string
+	}
+
+	l := New[item]()
+	first := l.PushBack(item{2, "a"})
+	l.PushBack(item{1, "b"})
+	l.PushBack(item{2, "c"})
+	l.PushBack(item{1, "d"})
+
+	l.Sort(func(a, b item) bool { return a.priority < b.priority })
+	requireListEquals(t, l, []item{{1, "b"}, {1, "d"}, {2, "a"}, {2, "c"}})
+
+	// Elements are relinked rather than copied, so remain usable
+	require.Equal(t, item{2, "a"}, l.Remove(first))
+	requireListEquals(t, l, []item{{1, "b"}, {1, "d"}, {2, "c"}})
+}
+
+func TestList_Slices(t *testing.T) {
+	l := FromSlice([]string{"foo", "bar", "zed"})
+	require.Equal(t, 3, l.Len())
+	requireListEquals(t, l, []string{"foo", "bar", "zed"})
+
+	require.Equal(t, []string{"foo", "bar", "zed"}, l.ToSlice())
+	require.Equal(t, []string{"mork", "foo", "bar", "zed"}, l.AppendTo([]string{"mork"}))
+
+	empty := FromSlice[string](nil)
+	require.Equal(t, 0, empty.Len())
+	require.Equal(t, []string{}, empty.ToSlice())
+	require.Nil(t, empty.AppendTo(nil))
+}
+
+func TestList_ElementPool(t *testing.T) {
+	l := New(WithElementPool[string]())
+	l.PushBack("foo")
+	bar := l.PushBack("bar")
+	l.PushBack("zed")
+
+	require.Equal(t, "bar", l.Remove(bar))
+	require.Equal(t, "", bar.Value)
+	require.Nil(t, bar.Next())
+	require.Nil(t, bar.Prev())
+	requireListEquals(t, l, []string{"foo", "zed"})
+
+	l.InsertAfter("mork", l.Front())
+	l.InsertBefore("ork", l.Back())
+	l.PushFront("first")
+	requireListEquals(t, l, []string{"first", "foo", "mork", "ork", "zed"})
+
+	l.Clear()
+	requireListEquals(t, l, []string{})
+
+	l.PushBack("again")
+	requireListEquals(t, l, []string{"again"})
+}
+
+func BenchmarkList_PushRemove(b *testing.B) {
+	for _, bb := range []struct {
+		name string
+		opts []Option[int]
+	}{
+		{"no pool", nil},
+		{"element pool", []Option[int]{WithElementPool[int]()}},
+	} {
+		b.Run(bb.name, func(b *testing.B) {
+			l := New(bb.opts...)
+			for i := 0; i < 1000; i++ {
+				l.PushBack(i)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				l.Remove(l.Front())
+				l.PushBack(i)
+			}
+		})
+	}
+}
+
+func TestList_Moves(t *testing.T) {
+	values := []string{"a", "b", "c", "d"}
+	for _, tt := range []struct {
+		name     string
+		move     func(l *List[string], elements []*Element[string])
+		expected []string
+	}{
+		{"front to back", func(l *List[string], e []*Element[string]) { l.MoveToBack(e[0]) },
+			[]string{"b", "c", "d", "a"}},
+		{"back to front", func(l *List[string], e []*Element[string]) { l.MoveToFront(e[3]) },
+			[]string{"d", "a", "b", "c"}},
+		{"middle to front", func(l *List[string], e []*Element[string]) { l.MoveToFront(e[2]) },
+			[]string{"c", "a", "b", "d"}},
+		{"middle to back", func(l *List[string], e []*Element[string]) { l.MoveToBack(e[1]) },
+			[]string{"a", "c", "d", "b"}},
+		{"front after back", func(l *List[string], e []*Element[string]) { l.MoveAfter(e[0], e[3]) },
+			[]string{"b", "c", "d", "a"}},
+		{"back before front", func(l *List[string], e []*Element[string]) { l.MoveBefore(e[3], e[0]) },
+			[]string{"d", "a", "b", "c"}},
+		{"front after middle", func(l *List[string], e []*Element[string]) { l.MoveAfter(e[0], e[1]) },
+			[]string{"b", "a", "c", "d"}},
+		{"back before middle", func(l *List[string], e []*Element[string]) { l.MoveBefore(e[3], e[2]) },
+			[]string{"a", "b", "d", "c"}},
+		{"back after previous", func(l *List[string], e []*Element[string]) { l.MoveAfter(e[3], e[2]) },
+			[]string{"a", "b", "c", "d"}},
+		{"move up", func(l *List[string], e []*Element[string]) { l.MoveUp(e[2]) },
+			[]string{"a", "c", "b", "d"}},
+		{"move up front", func(l *List[string], e []*Element[string]) { l.MoveUp(e[0]) },
+			[]string{"a", "b", "c", "d"}},
+		{"move up to front", func(l *List[string], e []*Element[string]) { l.MoveUp(e[1]) },
+			[]string{"b", "a", "c", "d"}},
+		{"move down", func(l *List[string], e []*Element[string]) { l.MoveDown(e[1]) },
+			[]string{"a", "c", "b", "d"}},
+		{"move down back", func(l *List[string], e []*Element[string]) { l.MoveDown(e[3]) },
+			[]string{"a", "b", "c", "d"}},
+		{"move down to back", func(l *List[string], e []*Element[string]) { l.MoveDown(e[2]) },
+			[]string{"a", "b", "d", "c"}},
+		{"swap ends", func(l *List[string], e []*Element[string]) { l.Swap(e[0], e[3]) },
+			[]string{"d", "b", "c", "a"}},
+		{"swap middle", func(l *List[string], e []*Element[string]) { l.Swap(e[2], e[1]) },
+			[]string{"a", "c", "b", "d"}},
+		{"swap adjacent front", func(l *List[string], e []*Element[string]) { l.Swap(e[0], e[1]) },
+			[]string{"b", "a", "c", "d"}},
+		{"swap adjacent back", func(l *List[string], e []*Element[string]) { l.Swap(e[3], e[2]) },
+			[]string{"a", "b", "d", "c"}},
+		{"swap non-adjacent", func(l *List[string], e []*Element[string]) { l.Swap(e[1], e[3]) },
+			[]string{"a", "d", "c", "b"}},
+		{"swap self", func(l *List[string], e []*Element[string]) { l.Swap(e[1], e[1]) },
+			[]string{"a", "b", "c", "d"}},
+		{"reverse", func(l *List[string], _ []*Element[string]) { l.Reverse() },
+			[]string{"d", "c", "b", "a"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			l := New[string]()
+			var elements []*Element[string]
+			for _, v := range values {
+				elements = append(elements, l.PushBack(v))
+			}
+
+			tt.move(l, elements)
+			require.Equal(t, len(values), l.Len())
+			requireListEquals(t, l, tt.expected)
+		})
+	}
+}
+
+func TestList_SwapNotMyList(t *testing.T) {
+	l1, l2 := FromSlice([]string{"a", "b"}), FromSlice([]string{"c", "d"})
+	l1.Swap(l1.Front(), l2.Front())
+	l1.MoveUp(l2.Back())
+	l1.MoveDown(l2.Front())
+	requireListEquals(t, l1, []string{"a", "b"})
+	requireListEquals(t, l2, []string{"c", "d"})
+}
+
+func TestList_ReverseEmpty(t *testing.T) {
+	l := New[string]()
+	l.Reverse()
+	requireListEquals(t, l, []string{})
+
+	l.PushBack("a")
+	l.Reverse()
+	requireListEquals(t, l, []string{"a"})
+}
+
+func TestList_Bounded(t *testing.T) {
+	var evicted []string
+	l := NewBounded(3, func(v string) {
+		evicted = append(evicted, v)
+	})
+
+	l.PushBack("a")
+	l.PushBack("b")
+	l.PushBack("c")
+	require.Empty(t, evicted)
+
+	// Pushing to the back evicts from the front, and vice versa
+	l.PushBack("d")
+	requireListEquals(t, l, []string{"b", "c", "d"})
+	l.PushFront("e")
+	requireListEquals(t, l, []string{"e", "b", "c"})
+	require.Equal(t, []string{"a", "d"}, evicted)
+
+	// Inserting in the middle evicts from the back
+	l.InsertAfter("f", l.Front())
+	requireListEquals(t, l, []string{"e", "f", "b"})
+
+	// Inserting at the back evicts from the front
+	l.InsertAfter("g", l.Back())
+	requireListEquals(t, l, []string{"f", "b", "g"})
+	require.Equal(t, []string{"a", "d", "c", "e"}, evicted)
+	require.Equal(t, 3, l.Len())
+}
+
+func TestList_BoundedNoCallback(t *testing.T) {
+	l := NewBounded[int](2, nil, WithElementPool[int]())
+	for i := 0; i < 10; i++ {
+		l.PushFront(i)
+	}
+
+	require.Equal(t, 2, l.Len())
+	requireListEquals(t, l, []int{9, 8})
+}
+
 func requireListEquals[V any](t *testing.T, l *List[V], fromFront []V) {
 	// Confirm result iterating from front
 	e := l.Front()
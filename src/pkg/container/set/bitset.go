@@ -0,0 +1,186 @@
+package set
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/bits"
+
+	"gopkg.in/yaml.v3"
+)
+
+const wordSize = 64
+
+// A Bitset is a set of non-negative integers, stored as a dense array of
+// bits. It uses far less memory than a Set[int] when the values are drawn
+// from a small range, but grows with the largest value in the set rather
+// than with the number of values. The zero value is an empty set ready to use.
+type Bitset struct {
+	words []uint64
+}
+
+// NewBitset creates a new bitset from a collection of values.
+func NewBitset(vals ...int) *Bitset {
+	return (&Bitset{}).Add(vals...)
+}
+
+// Add adds values to the set. Panics if any of the values are negative.
+func (b *Bitset) Add(vals ...int) *Bitset {
+	for _, v := range vals {
+		if v < 0 {
+			panic(fmt.Sprintf("cannot add negative value %d to Bitset", v))
+		}
+
+		i := v / wordSize
+		if i >= len(b.words) {
+			b.words = append(b.words, make([]uint64, i-len(b.words)+1)...)
+		}
+
+		b.words[i] |= 1 << (v % wordSize)
+	}
+	return b
+}
+
+// Del removes values from the set.
+func (b *Bitset) Del(vals ...int) *Bitset {
+	for _, v := range vals {
+		if v >= 0 && v/wordSize < len(b.words) {
+			b.words[v/wordSize] &^= 1 << (v % wordSize)
+		}
+	}
+	return b
+}
+
+// Has returns true if the set contains the given value.
+func (b *Bitset) Has(val int) bool {
+	return val >= 0 && val/wordSize < len(b.words) && b.words[val/wordSize]&(1<<(val%wordSize)) != 0
+}
+
+// Len returns the size of the set.
+func (b *Bitset) Len() int {
+	var n int
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// All returns all values in the set, in ascending order.
+func (b *Bitset) All() []int {
+	result := make([]int, 0, b.Len())
+	for v := range b.Values() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Values returns an iterator over the values in the set, in ascending order.
+func (b *Bitset) Values() iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i, w := range b.words {
+			for w != 0 {
+				bit := bits.TrailingZeros64(w)
+				if !yield(i*wordSize + bit) {
+					return
+				}
+				w &^= 1 << bit
+			}
+		}
+	}
+}
+
+// And computes the intersection of two bitsets.
+func (b *Bitset) And(other *Bitset) *Bitset {
+	result := &Bitset{words: make([]uint64, min(len(b.words), len(other.words)))}
+	for i := range result.words {
+		result.words[i] = b.words[i] & other.words[i]
+	}
+	return result
+}
+
+// Or computes the union of two bitsets.
+func (b *Bitset) Or(other *Bitset) *Bitset {
+	result := &Bitset{words: make([]uint64, max(len(b.words), len(other.words)))}
+	copy(result.words, b.words)
+	for i, w := range other.words {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// AndNot computes the difference of two bitsets, producing a bitset
+// that contains the values present in this set but not in the other.
+func (b *Bitset) AndNot(other *Bitset) *Bitset {
+	result := &Bitset{words: make([]uint64, len(b.words))}
+	copy(result.words, b.words)
+	for i := range min(len(result.words), len(other.words)) {
+		result.words[i] &^= other.words[i]
+	}
+	return result
+}
+
+// Contains returns true if one set contains all elements of the other.
+func (b *Bitset) Contains(other *Bitset) bool {
+	for i, w := range other.words {
+		var mine uint64
+		if i < len(b.words) {
+			mine = b.words[i]
+		}
+
+		if w&^mine != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal compares two bitsets for exact equality.
+func (b *Bitset) Equal(other *Bitset) bool {
+	return b.Contains(other) && other.Contains(b)
+}
+
+// ToSet returns the values of the bitset as a Set.
+func (b *Bitset) ToSet() Set[int] {
+	return New(b.All()...)
+}
+
+// UnmarshalJSON unmarshals a bitset from a JSON array.
+func (b *Bitset) UnmarshalJSON(data []byte) error {
+	var val []int
+	if err := json.Unmarshal(data, &val); err != nil {
+		return err
+	}
+
+	return b.set(val)
+}
+
+// MarshalJSON marshals a bitset as a JSON array, in ascending order.
+func (b Bitset) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.All())
+}
+
+// UnmarshalYAML unmarshals a bitset from a YAML array.
+func (b *Bitset) UnmarshalYAML(n *yaml.Node) error {
+	var val []int
+	if err := n.Decode(&val); err != nil {
+		return err
+	}
+
+	return b.set(val)
+}
+
+// MarshalYAML marshals a bitset as a YAML array, in ascending order.
+func (b Bitset) MarshalYAML() (any, error) {
+	return b.All(), nil
+}
+
+func (b *Bitset) set(vals []int) error {
+	for _, v := range vals {
+		if v < 0 {
+			return fmt.Errorf("invalid Bitset value %d: must not be negative", v)
+		}
+	}
+
+	*b = *NewBitset(vals...)
+	return nil
+}
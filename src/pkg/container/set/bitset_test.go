@@ -0,0 +1,103 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBitset(t *testing.T) {
+	b := NewBitset(3, 64, 1, 200, 3)
+	assert.True(t, b.Has(1))
+	assert.True(t, b.Has(64))
+	assert.True(t, b.Has(200))
+	assert.False(t, b.Has(2))
+	assert.False(t, b.Has(-1))
+	assert.False(t, b.Has(1000))
+	assert.Equal(t, 4, b.Len())
+	assert.Equal(t, []int{1, 3, 64, 200}, b.All())
+
+	b.Add(63).Del(64, 1000, -1)
+	assert.Equal(t, []int{1, 3, 63, 200}, b.All())
+	assert.True(t, b.ToSet().Equal(New(1, 3, 63, 200)))
+
+	assert.Panics(t, func() { b.Add(-1) })
+}
+
+func TestBitset_ZeroValue(t *testing.T) {
+	var b Bitset
+	assert.Equal(t, 0, b.Len())
+	assert.Empty(t, b.All())
+	assert.False(t, b.Has(0))
+
+	b.Add(0)
+	assert.Equal(t, []int{0}, b.All())
+}
+
+func TestBitset_Values(t *testing.T) {
+	var all []int
+	for v := range NewBitset(130, 5, 64).Values() {
+		all = append(all, v)
+		if len(all) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{5, 64}, all)
+}
+
+func TestBitset_SetOperations(t *testing.T) {
+	b1 := NewBitset(1, 2, 3, 100, 300)
+	b2 := NewBitset(2, 3, 4, 100)
+
+	assert.Equal(t, []int{2, 3, 100}, b1.And(b2).All())
+	assert.Equal(t, []int{1, 2, 3, 4, 100, 300}, b1.Or(b2).All())
+	assert.Equal(t, []int{1, 300}, b1.AndNot(b2).All())
+	assert.Equal(t, []int{4}, b2.AndNot(b1).All())
+
+	assert.True(t, b1.Contains(NewBitset(1, 300)))
+	assert.False(t, b1.Contains(b2))
+	assert.True(t, b1.Contains(&Bitset{}))
+
+	// Trailing empty words do not affect equality
+	assert.True(t, NewBitset(1, 500).Del(500).Equal(NewBitset(1)))
+	assert.False(t, b1.Equal(b2))
+}
+
+func TestBitset_Marshalling(t *testing.T) {
+	b := NewBitset(65, 3, 1)
+
+	output, err := json.Marshal(b)
+	require.NoError(t, err)
+	assert.Equal(t, `[1,3,65]`, string(output))
+
+	var fromJSON Bitset
+	require.NoError(t, json.Unmarshal(output, &fromJSON))
+	assert.True(t, fromJSON.Equal(b))
+	assert.Error(t, json.Unmarshal([]byte(`[1, -1]`), &fromJSON))
+
+	output, err = yaml.Marshal(b)
+	require.NoError(t, err)
+
+	var fromYAML Bitset
+	require.NoError(t, yaml.Unmarshal(output, &fromYAML))
+	assert.True(t, fromYAML.Equal(b))
+}
+
+func TestBitset_MarshallingByValue(t *testing.T) {
+	type doc struct {
+		Days Bitset `json:"days" yaml:"days"`
+	}
+
+	d := doc{Days: *NewBitset(5, 1)}
+
+	output, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, `{"days":[1,5]}`, string(output))
+
+	output, err = yaml.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, "days:\n    - 1\n    - 5\n", string(output))
+}
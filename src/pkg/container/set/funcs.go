@@ -0,0 +1,59 @@
+package set
+
+// Filter returns a new set holding the values that match the predicate.
+func (set Set[E]) Filter(pred func(v E) bool) Set[E] {
+	result := New[E]()
+	for v := range set {
+		if pred(v) {
+			result.Add(v)
+		}
+	}
+	return result
+}
+
+// Partition splits the set into the values that match the predicate and
+// the values that do not.
+func (set Set[E]) Partition(pred func(v E) bool) (matched, unmatched Set[E]) {
+	matched, unmatched = New[E](), New[E]()
+	for v := range set {
+		if pred(v) {
+			matched.Add(v)
+		} else {
+			unmatched.Add(v)
+		}
+	}
+	return matched, unmatched
+}
+
+// Any returns true if at least one value in the set matches the predicate.
+func (set Set[E]) Any(pred func(v E) bool) bool {
+	for v := range set {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every returns true if all values in the set match the predicate, and
+// true for an empty set. It is named Every rather than All since All
+// returns the values of the set.
+func (set Set[E]) Every(pred func(v E) bool) bool {
+	for v := range set {
+		if !pred(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// MapTo returns a new set holding the result of applying f to each value
+// of the set. Values that map to the same result are merged, so the new
+// set may be smaller than the original.
+func MapTo[E, F comparable](set Set[E], f func(v E) F) Set[F] {
+	result := make(Set[F], len(set))
+	for v := range set {
+		result.Add(f(v))
+	}
+	return result
+}
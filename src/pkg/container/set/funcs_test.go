@@ -0,0 +1,52 @@
+package set
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func isEven(v int) bool { return v%2 == 0 }
+
+func TestSet_Filter(t *testing.T) {
+	set := New(1, 2, 3, 4, 5, 6)
+	assert.True(t, set.Filter(isEven).Equal(New(2, 4, 6)))
+	assert.Equal(t, 0, New(1, 3).Filter(isEven).Len())
+	assert.Equal(t, 6, set.Len())
+}
+
+func TestSet_Partition(t *testing.T) {
+	even, odd := New(1, 2, 3, 4, 5, 6).Partition(isEven)
+	assert.True(t, even.Equal(New(2, 4, 6)))
+	assert.True(t, odd.Equal(New(1, 3, 5)))
+
+	even, odd = New[int]().Partition(isEven)
+	assert.Equal(t, 0, even.Len())
+	assert.Equal(t, 0, odd.Len())
+}
+
+func TestSet_AnyEvery(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		set   Set[int]
+		any   bool
+		every bool
+	}{
+		{"mixed", New(1, 2, 3), true, false},
+		{"all match", New(2, 4), true, true},
+		{"none match", New(1, 3), false, false},
+		{"empty", New[int](), false, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.any, tt.set.Any(isEven))
+			assert.Equal(t, tt.every, tt.set.Every(isEven))
+		})
+	}
+}
+
+func TestMapTo(t *testing.T) {
+	set := New("a", "b", "A", "c")
+	assert.True(t, MapTo(set, strings.ToUpper).Equal(New("A", "B", "C")))
+	assert.True(t, MapTo(set, func(v string) int { return len(v) }).Equal(New(1)))
+}
@@ -0,0 +1,141 @@
+package set
+
+// A Multiset (or bag) is an unordered collection of values that tracks how
+// many times each value has been added.
+type Multiset[E comparable] map[E]int
+
+// NewMultiset creates a new multiset from a collection of values, counting
+// repeated values.
+func NewMultiset[E comparable](vals ...E) Multiset[E] {
+	m := make(Multiset[E], len(vals))
+	m.Add(vals...)
+	return m
+}
+
+// Add adds one occurrence of each of the values to the multiset.
+func (m Multiset[E]) Add(vals ...E) Multiset[E] {
+	for _, v := range vals {
+		m[v]++
+	}
+	return m
+}
+
+// AddN adds n occurrences of a value to the multiset. Adding a negative
+// number of occurrences removes them.
+func (m Multiset[E]) AddN(val E, n int) Multiset[E] {
+	if count := m[val] + n; count > 0 {
+		m[val] = count
+	} else {
+		delete(m, val)
+	}
+	return m
+}
+
+// Remove removes one occurrence of each of the values from the multiset.
+// Values are removed entirely once their count reaches zero.
+func (m Multiset[E]) Remove(vals ...E) Multiset[E] {
+	for _, v := range vals {
+		m.AddN(v, -1)
+	}
+	return m
+}
+
+// RemoveAll removes all occurrences of the values from the multiset.
+func (m Multiset[E]) RemoveAll(vals ...E) Multiset[E] {
+	for _, v := range vals {
+		delete(m, v)
+	}
+	return m
+}
+
+// Count returns the number of occurrences of a value in the multiset.
+func (m Multiset[E]) Count(val E) int {
+	return m[val]
+}
+
+// Has returns true if the multiset contains at least one occurrence of a value.
+func (m Multiset[E]) Has(val E) bool {
+	return m[val] > 0
+}
+
+// Len returns the total number of occurrences of all values in the multiset.
+func (m Multiset[E]) Len() int {
+	var n int
+	for _, count := range m {
+		n += count
+	}
+	return n
+}
+
+// Distinct returns the number of distinct values in the multiset.
+func (m Multiset[E]) Distinct() int {
+	return len(m)
+}
+
+// Union computes the union of two multisets, producing a multiset where
+// each value occurs the maximum number of times it occurs in either multiset.
+func (m Multiset[E]) Union(other Multiset[E]) Multiset[E] {
+	result := make(Multiset[E], len(m))
+	for v, count := range m {
+		result[v] = count
+	}
+
+	for v, count := range other {
+		result[v] = max(result[v], count)
+	}
+
+	return result
+}
+
+// Intersect computes the intersection of two multisets, producing a multiset
+// where each value occurs the minimum number of times it occurs in either
+// multiset.
+func (m Multiset[E]) Intersect(other Multiset[E]) Multiset[E] {
+	result := make(Multiset[E])
+	for v, count := range m {
+		if n := min(count, other[v]); n > 0 {
+			result[v] = n
+		}
+	}
+
+	return result
+}
+
+// Sum computes the sum of two multisets, producing a multiset where the
+// counts of each value are added together.
+func (m Multiset[E]) Sum(other Multiset[E]) Multiset[E] {
+	result := make(Multiset[E], len(m))
+	for v, count := range m {
+		result[v] = count
+	}
+
+	for v, count := range other {
+		result[v] += count
+	}
+
+	return result
+}
+
+// ToSet returns the distinct values of the multiset as a Set.
+func (m Multiset[E]) ToSet() Set[E] {
+	result := make(Set[E], len(m))
+	for v := range m {
+		result.Add(v)
+	}
+	return result
+}
+
+// Equal compares two multisets for exact equality, including counts.
+func (m Multiset[E]) Equal(other Multiset[E]) bool {
+	if len(m) != len(other) {
+		return false
+	}
+
+	for v, count := range m {
+		if other[v] != count {
+			return false
+		}
+	}
+
+	return true
+}
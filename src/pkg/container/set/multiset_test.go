@@ -0,0 +1,53 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiset(t *testing.T) {
+	m := NewMultiset("a", "b", "a", "c", "a")
+	assert.Equal(t, 3, m.Count("a"))
+	assert.Equal(t, 1, m.Count("b"))
+	assert.Equal(t, 0, m.Count("z"))
+	assert.True(t, m.Has("c"))
+	assert.False(t, m.Has("z"))
+	assert.Equal(t, 5, m.Len())
+	assert.Equal(t, 3, m.Distinct())
+
+	m.Add("b").Remove("a", "c", "z")
+	assert.Equal(t, 2, m.Count("a"))
+	assert.Equal(t, 2, m.Count("b"))
+	assert.False(t, m.Has("c"))
+	assert.Equal(t, 2, m.Distinct())
+
+	m.AddN("d", 4).AddN("a", -5).RemoveAll("b")
+	assert.True(t, m.Equal(NewMultiset("d", "d", "d", "d")))
+	assert.True(t, m.ToSet().Equal(New("d")))
+}
+
+func TestMultiset_SetOperations(t *testing.T) {
+	m1 := NewMultiset("a", "a", "b", "c", "c", "c")
+	m2 := NewMultiset("a", "b", "b", "d")
+
+	assert.True(t, m1.Union(m2).Equal(Multiset[string]{"a": 2, "b": 2, "c": 3, "d": 1}))
+	assert.True(t, m1.Intersect(m2).Equal(Multiset[string]{"a": 1, "b": 1}))
+	assert.True(t, m1.Sum(m2).Equal(Multiset[string]{"a": 3, "b": 3, "c": 3, "d": 1}))
+
+	// The operations do not modify either multiset
+	assert.Equal(t, 6, m1.Len())
+	assert.Equal(t, 4, m2.Len())
+}
+
+func TestMultiset_JSON(t *testing.T) {
+	output, err := json.Marshal(NewMultiset("a", "b", "a"))
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":2,"b":1}`, string(output))
+
+	var m Multiset[string]
+	require.NoError(t, json.Unmarshal(output, &m))
+	assert.True(t, m.Equal(NewMultiset("a", "a", "b")))
+}
@@ -0,0 +1,145 @@
+package set
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// An OrderedSet is a set that keeps its values in ascending order, so that
+// iteration and marshalling are deterministic. Values are held in a sorted
+// slice, so lookups are O(log n) and insertions and removals are O(n). The
+// zero value is an empty set ready to use.
+type OrderedSet[E cmp.Ordered] struct {
+	vals []E
+}
+
+// NewOrdered creates a new ordered set from a collection of values.
+func NewOrdered[E cmp.Ordered](vals ...E) *OrderedSet[E] {
+	vals = slices.Clone(vals)
+	slices.Sort(vals)
+	return &OrderedSet[E]{vals: slices.Compact(vals)}
+}
+
+// Add adds values to the set.
+func (set *OrderedSet[E]) Add(vals ...E) *OrderedSet[E] {
+	for _, v := range vals {
+		if i, found := slices.BinarySearch(set.vals, v); !found {
+			set.vals = slices.Insert(set.vals, i, v)
+		}
+	}
+	return set
+}
+
+// Del removes values from the set.
+func (set *OrderedSet[E]) Del(vals ...E) *OrderedSet[E] {
+	for _, v := range vals {
+		if i, found := slices.BinarySearch(set.vals, v); found {
+			set.vals = slices.Delete(set.vals, i, i+1)
+		}
+	}
+	return set
+}
+
+// Has returns true if the set contains the given value.
+func (set *OrderedSet[E]) Has(val E) bool {
+	_, found := slices.BinarySearch(set.vals, val)
+	return found
+}
+
+// All returns all values in the set, in ascending order.
+func (set *OrderedSet[E]) All() []E {
+	return slices.Clone(set.vals)
+}
+
+// Values returns an iterator over the values in the set, in ascending order.
+// The set must not be modified during iteration.
+func (set *OrderedSet[E]) Values() iter.Seq[E] {
+	return slices.Values(set.vals)
+}
+
+// Len returns the size of the set.
+func (set *OrderedSet[E]) Len() int {
+	return len(set.vals)
+}
+
+// Min returns the smallest value in the set. Returns false if the set is empty.
+func (set *OrderedSet[E]) Min() (E, bool) {
+	if len(set.vals) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	return set.vals[0], true
+}
+
+// Max returns the largest value in the set. Returns false if the set is empty.
+func (set *OrderedSet[E]) Max() (E, bool) {
+	if len(set.vals) == 0 {
+		var zero E
+		return zero, false
+	}
+
+	return set.vals[len(set.vals)-1], true
+}
+
+// RangeBetween returns the values in the set between from and to inclusive,
+// in ascending order.
+func (set *OrderedSet[E]) RangeBetween(from, to E) []E {
+	start, _ := slices.BinarySearch(set.vals, from)
+	end, found := slices.BinarySearch(set.vals, to)
+	if found {
+		end++
+	}
+
+	if start >= end {
+		return []E{}
+	}
+
+	return slices.Clone(set.vals[start:end])
+}
+
+// ToSet returns the values of the ordered set as an unordered Set.
+func (set *OrderedSet[E]) ToSet() Set[E] {
+	return New(set.vals...)
+}
+
+// Equal compares two ordered sets for exact equality.
+func (set *OrderedSet[E]) Equal(other *OrderedSet[E]) bool {
+	return slices.Equal(set.vals, other.vals)
+}
+
+// UnmarshalJSON unmarshals a set from a JSON array.
+func (set *OrderedSet[E]) UnmarshalJSON(b []byte) error {
+	var val []E
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	*set = *NewOrdered(val...)
+	return nil
+}
+
+// MarshalJSON marshals a set as a JSON array, in ascending order.
+func (set OrderedSet[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.All())
+}
+
+// UnmarshalYAML unmarshals a set from a YAML array.
+func (set *OrderedSet[E]) UnmarshalYAML(n *yaml.Node) error {
+	var val []E
+	if err := n.Decode(&val); err != nil {
+		return err
+	}
+
+	*set = *NewOrdered(val...)
+	return nil
+}
+
+// MarshalYAML marshals a set as a YAML array, in ascending order.
+func (set OrderedSet[E]) MarshalYAML() (any, error) {
+	return set.All(), nil
+}
@@ -0,0 +1,119 @@
+package set
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestOrderedSet(t *testing.T) {
+	set := NewOrdered("d", "b", "a", "c", "b")
+	assert.Equal(t, []string{"a", "b", "c", "d"}, set.All())
+	assert.Equal(t, 4, set.Len())
+	assert.True(t, set.Has("c"))
+	assert.False(t, set.Has("e"))
+
+	set.Add("g", "f", "a").Del("b", "z")
+	assert.Equal(t, []string{"a", "c", "d", "f", "g"}, set.All())
+	assert.True(t, set.ToSet().Equal(New("a", "c", "d", "f", "g")))
+	assert.True(t, set.Equal(NewOrdered("g", "f", "d", "c", "a")))
+	assert.False(t, set.Equal(NewOrdered("a")))
+}
+
+func TestOrderedSet_Values(t *testing.T) {
+	var all []int
+	for v := range NewOrdered(5, 3, 9, 1).Values() {
+		all = append(all, v)
+	}
+	assert.Equal(t, []int{1, 3, 5, 9}, all)
+}
+
+func TestOrderedSet_ZeroValue(t *testing.T) {
+	var set OrderedSet[int]
+	assert.Equal(t, 0, set.Len())
+	assert.Empty(t, set.All())
+
+	_, ok := set.Min()
+	assert.False(t, ok)
+	_, ok = set.Max()
+	assert.False(t, ok)
+
+	set.Add(3, 1, 2)
+	assert.Equal(t, []int{1, 2, 3}, set.All())
+}
+
+func TestOrderedSet_MinMax(t *testing.T) {
+	set := NewOrdered(5, 3, 9, 1)
+
+	minVal, ok := set.Min()
+	require.True(t, ok)
+	assert.Equal(t, 1, minVal)
+
+	maxVal, ok := set.Max()
+	require.True(t, ok)
+	assert.Equal(t, 9, maxVal)
+}
+
+func TestOrderedSet_RangeBetween(t *testing.T) {
+	set := NewOrdered(1, 3, 5, 7, 9)
+	for _, tt := range []struct {
+		name     string
+		from, to int
+		expected []int
+	}{
+		{"inclusive", 3, 7, []int{3, 5, 7}},
+		{"between values", 2, 8, []int{3, 5, 7}},
+		{"everything", 0, 10, []int{1, 3, 5, 7, 9}},
+		{"single", 5, 5, []int{5}},
+		{"no values", 4, 4, []int{}},
+		{"reversed", 7, 3, []int{}},
+		{"past the end", 10, 20, []int{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, set.RangeBetween(tt.from, tt.to))
+		})
+	}
+}
+
+func TestOrderedSet_Marshalling(t *testing.T) {
+	set := NewOrdered("d", "b", "a", "c")
+
+	output, err := json.Marshal(set)
+	require.NoError(t, err)
+	assert.Equal(t, `["a","b","c","d"]`, string(output))
+
+	var fromJSON OrderedSet[string]
+	require.NoError(t, json.Unmarshal([]byte(`["z", "y", "z"]`), &fromJSON))
+	assert.Equal(t, []string{"y", "z"}, fromJSON.All())
+
+	output, err = yaml.Marshal(set)
+	require.NoError(t, err)
+	assert.Equal(t, "- a\n- b\n- c\n- d\n", string(output))
+
+	var fromYAML OrderedSet[string]
+	require.NoError(t, yaml.Unmarshal([]byte(`["z", "y"]`), &fromYAML))
+	assert.Equal(t, []string{"y", "z"}, fromYAML.All())
+}
+
+func TestOrderedSet_MarshallingByValue(t *testing.T) {
+	type doc struct {
+		Tags OrderedSet[string] `json:"tags" yaml:"tags"`
+	}
+
+	d := doc{Tags: *NewOrdered("b", "a")}
+
+	output, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, `{"tags":["a","b"]}`, string(output))
+
+	output, err = yaml.Marshal(d)
+	require.NoError(t, err)
+	assert.Equal(t, "tags:\n    - a\n    - b\n", string(output))
+
+	var fromJSON doc
+	require.NoError(t, json.Unmarshal([]byte(`{"tags":["z","y"]}`), &fromJSON))
+	assert.Equal(t, []string{"y", "z"}, fromJSON.Tags.All())
+}
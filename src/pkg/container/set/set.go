@@ -3,6 +3,8 @@ package set
 
 import (
 	"encoding/json"
+	"iter"
+	"sort"
 
 	"gopkg.in/yaml.v3"
 )
@@ -36,6 +38,48 @@ func (set Set[E]) Del(vals ...E) Set[E] {
 	return set
 }
 
+// Clone returns a copy of the set.
+func (set Set[E]) Clone() Set[E] {
+	result := make(Set[E], len(set))
+	for v := range set {
+		result.Add(v)
+	}
+	return result
+}
+
+// AddSet adds all values of the other set to the set in place, returning
+// true if the set changed.
+func (set Set[E]) AddSet(other Set[E]) bool {
+	n := len(set)
+	for v := range other {
+		set.Add(v)
+	}
+	return len(set) != n
+}
+
+// DelSet removes all values of the other set from the set in place,
+// returning true if the set changed.
+func (set Set[E]) DelSet(other Set[E]) bool {
+	n := len(set)
+	for v := range other {
+		delete(set, v)
+	}
+	return len(set) != n
+}
+
+// RetainOnly removes all values that are not in the other set from the set
+// in place, leaving the intersection of the two sets. Returns true if the
+// set changed.
+func (set Set[E]) RetainOnly(other Set[E]) bool {
+	n := len(set)
+	for v := range set {
+		if !other.Has(v) {
+			delete(set, v)
+		}
+	}
+	return len(set) != n
+}
+
 // Has returns true if the set contains the given value.
 func (set Set[E]) Has(val E) bool {
 	_, ok := set[val]
@@ -51,6 +95,26 @@ func (set Set[E]) All() []E {
 	return result
 }
 
+// Values returns an iterator over the values in the set, in no particular order.
+func (set Set[E]) Values() iter.Seq[E] {
+	return func(yield func(E) bool) {
+		for v := range set {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Sorted returns all values in the set, ordered by the given less function.
+func (set Set[E]) Sorted(less func(a, b E) bool) []E {
+	result := set.All()
+	sort.Slice(result, func(i, j int) bool {
+		return less(result[i], result[j])
+	})
+	return result
+}
+
 // Len returns the size of the set.
 func (set Set[E]) Len() int {
 	return len(set)
@@ -84,6 +148,33 @@ func (set Set[E]) Union(other Set[E]) Set[E] {
 	return result
 }
 
+// Difference computes the difference of two sets, producing a set
+// that contains the values present in this set but not in the other.
+func (set Set[E]) Difference(other Set[E]) Set[E] {
+	result := New[E]()
+	for v := range set {
+		if !other.Has(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
+// SymmetricDifference computes the symmetric difference of two sets,
+// producing a set that contains the values present in exactly one of
+// the sets.
+func (set Set[E]) SymmetricDifference(other Set[E]) Set[E] {
+	result := set.Difference(other)
+	for v := range other {
+		if !set.Has(v) {
+			result.Add(v)
+		}
+	}
+
+	return result
+}
+
 // Contains returns true if one set contains all
 // elements of the other.
 func (set Set[E]) Contains(other Set[E]) bool {
@@ -30,6 +30,72 @@ func TestSet(t *testing.T) {
 	assert.Equal(t, []string{"b", "c", "d", "f", "g"}, all)
 }
 
+func TestSet_Values(t *testing.T) {
+	set := New("a", "b", "c", "d")
+
+	var all []string
+	for v := range set.Values() {
+		all = append(all, v)
+	}
+	sort.Strings(all)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, all)
+
+	// Stops when the loop breaks
+	var count int
+	for range set.Values() {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestSet_Sorted(t *testing.T) {
+	set := New(5, 3, 9, 1)
+	assert.Equal(t, []int{1, 3, 5, 9}, set.Sorted(func(a, b int) bool { return a < b }))
+	assert.Equal(t, []int{9, 5, 3, 1}, set.Sorted(func(a, b int) bool { return a > b }))
+	assert.Empty(t, New[int]().Sorted(func(a, b int) bool { return a < b }))
+}
+
+func TestSet_Clone(t *testing.T) {
+	set := New("a", "b", "c")
+	clone := set.Clone()
+	assert.True(t, clone.Equal(set))
+
+	clone.Add("d")
+	assert.False(t, set.Has("d"))
+}
+
+func TestSet_InPlace(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		op       func(set, other Set[string]) bool
+		set      Set[string]
+		other    Set[string]
+		changed  bool
+		expected Set[string]
+	}{
+		{"add set", Set[string].AddSet,
+			New("a", "b"), New("b", "c"), true, New("a", "b", "c")},
+		{"add subset", Set[string].AddSet,
+			New("a", "b"), New("b"), false, New("a", "b")},
+		{"del set", Set[string].DelSet,
+			New("a", "b"), New("b", "c"), true, New("a")},
+		{"del disjoint set", Set[string].DelSet,
+			New("a", "b"), New("c"), false, New("a", "b")},
+		{"retain only", Set[string].RetainOnly,
+			New("a", "b", "c"), New("b", "c", "d"), true, New("b", "c")},
+		{"retain superset", Set[string].RetainOnly,
+			New("a", "b"), New("a", "b", "c"), false, New("a", "b")},
+		{"retain nothing", Set[string].RetainOnly,
+			New("a", "b"), New[string](), true, New[string]()},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.changed, tt.op(tt.set, tt.other))
+			assert.True(t, tt.set.Equal(tt.expected), "%v", tt.set.All())
+		})
+	}
+}
+
 func TestSet_Intersect(t *testing.T) {
 	set1 := New("a", "b", "c", "d")
 	set2 := New("a", "c", "g", "f")
@@ -60,6 +126,37 @@ func TestSet_Union(t *testing.T) {
 	assert.Equal(t, []string{"a", "b", "c", "d", "f", "g"}, all)
 }
 
+func TestSet_Difference(t *testing.T) {
+	set1 := New("a", "b", "c", "d")
+	set2 := New("a", "c", "g", "f")
+
+	all := set1.Difference(set2).All()
+	sort.Strings(all)
+	assert.Equal(t, []string{"b", "d"}, all)
+
+	all = set2.Difference(set1).All()
+	sort.Strings(all)
+	assert.Equal(t, []string{"f", "g"}, all)
+
+	assert.Equal(t, 0, set1.Difference(set1).Len())
+	assert.True(t, set1.Difference(New[string]()).Equal(set1))
+}
+
+func TestSet_SymmetricDifference(t *testing.T) {
+	set1 := New("a", "b", "c", "d")
+	set2 := New("a", "c", "g", "f")
+
+	all := set1.SymmetricDifference(set2).All()
+	sort.Strings(all)
+	assert.Equal(t, []string{"b", "d", "f", "g"}, all)
+
+	all = set2.SymmetricDifference(set1).All()
+	sort.Strings(all)
+	assert.Equal(t, []string{"b", "d", "f", "g"}, all)
+
+	assert.Equal(t, 0, set1.SymmetricDifference(set1).Len())
+}
+
 func TestSet_Contains(t *testing.T) {
 	set1 := New("a", "b", "c", "d")
 	assert.True(t, set1.Contains(New("a", "b")))
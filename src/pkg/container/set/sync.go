@@ -0,0 +1,178 @@
+package set
+
+import (
+	"encoding/json"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Sync is a Set that is safe for concurrent use. Operations that combine a
+// Sync with another set take a plain Set, which can be obtained from another
+// Sync with Snapshot. The zero value is an empty set ready to use.
+type Sync[E comparable] struct {
+	mu  sync.RWMutex
+	set Set[E]
+}
+
+// NewSync creates a new concurrency-safe set from a collection of values.
+func NewSync[E comparable](vals ...E) *Sync[E] {
+	return &Sync[E]{set: New(vals...)}
+}
+
+// Add adds values to the set.
+func (s *Sync[E]) Add(vals ...E) *Sync[E] {
+	s.AddAll(vals...)
+	return s
+}
+
+// AddAll atomically adds values to the set, returning the number
+// of values that were not already in the set.
+func (s *Sync[E]) AddAll(vals ...E) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.set == nil {
+		s.set = New[E]()
+	}
+
+	var added int
+	for _, v := range vals {
+		if !s.set.Has(v) {
+			s.set.Add(v)
+			added++
+		}
+	}
+
+	return added
+}
+
+// Del removes values from the set.
+func (s *Sync[E]) Del(vals ...E) *Sync[E] {
+	s.DelAll(vals...)
+	return s
+}
+
+// DelAll atomically removes values from the set, returning the number
+// of values that were in the set.
+func (s *Sync[E]) DelAll(vals ...E) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var removed int
+	for _, v := range vals {
+		if s.set.Has(v) {
+			s.set.Del(v)
+			removed++
+		}
+	}
+
+	return removed
+}
+
+// Has returns true if the set contains the given value.
+func (s *Sync[E]) Has(val E) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Has(val)
+}
+
+// All returns all values in the set.
+func (s *Sync[E]) All() []E {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.All()
+}
+
+// Len returns the size of the set.
+func (s *Sync[E]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Len()
+}
+
+// Snapshot returns a copy of the set's current values as a plain Set.
+func (s *Sync[E]) Snapshot() Set[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return New(s.set.All()...)
+}
+
+// Intersect computes the intersection of the set with another set.
+func (s *Sync[E]) Intersect(other Set[E]) Set[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Intersect(other)
+}
+
+// Union computes the union of the set with another set.
+func (s *Sync[E]) Union(other Set[E]) Set[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Union(other)
+}
+
+// Difference computes the values in the set that are not in another set.
+func (s *Sync[E]) Difference(other Set[E]) Set[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Difference(other)
+}
+
+// SymmetricDifference computes the values present in exactly one of the
+// set and another set.
+func (s *Sync[E]) SymmetricDifference(other Set[E]) Set[E] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.SymmetricDifference(other)
+}
+
+// Contains returns true if the set contains all elements of another set.
+func (s *Sync[E]) Contains(other Set[E]) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Contains(other)
+}
+
+// Equal compares the set with another set for exact equality.
+func (s *Sync[E]) Equal(other Set[E]) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.set.Equal(other)
+}
+
+// UnmarshalJSON unmarshals a set from a JSON array.
+func (s *Sync[E]) UnmarshalJSON(b []byte) error {
+	var val Set[E]
+	if err := json.Unmarshal(b, &val); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = val
+	return nil
+}
+
+// MarshalJSON marshals a set as a JSON array.
+func (s *Sync[E]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.All())
+}
+
+// UnmarshalYAML unmarshals a set from a YAML array.
+func (s *Sync[E]) UnmarshalYAML(n *yaml.Node) error {
+	var val Set[E]
+	if err := n.Decode(&val); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.set = val
+	return nil
+}
+
+// MarshalYAML marshals a set as a YAML array.
+func (s *Sync[E]) MarshalYAML() (any, error) {
+	return s.All(), nil
+}
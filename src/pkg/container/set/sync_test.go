@@ -0,0 +1,98 @@
+package set
+
+import (
+	"encoding/json"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestSync(t *testing.T) {
+	set := NewSync("a", "b", "c", "d")
+	assert.True(t, set.Has("a"))
+	assert.False(t, set.Has("f"))
+	assert.Equal(t, 4, set.Len())
+
+	set.Add("f").Add("g").Del("a")
+	all := set.All()
+	sort.Strings(all)
+	assert.Equal(t, []string{"b", "c", "d", "f", "g"}, all)
+
+	assert.Equal(t, 1, set.AddAll("b", "h", "h"))
+	assert.Equal(t, 2, set.DelAll("b", "c", "z"))
+	assert.True(t, set.Equal(New("d", "f", "g", "h")))
+}
+
+func TestSync_ZeroValue(t *testing.T) {
+	var set Sync[string]
+	assert.False(t, set.Has("a"))
+	assert.Equal(t, 0, set.DelAll("a"))
+	assert.Equal(t, 2, set.AddAll("a", "b"))
+	assert.True(t, set.Equal(New("a", "b")))
+}
+
+func TestSync_SetOperations(t *testing.T) {
+	set := NewSync("a", "b", "c", "d")
+	other := New("a", "c", "g", "f")
+
+	assert.True(t, set.Intersect(other).Equal(New("a", "c")))
+	assert.True(t, set.Union(other).Equal(New("a", "b", "c", "d", "f", "g")))
+	assert.True(t, set.Difference(other).Equal(New("b", "d")))
+	assert.True(t, set.SymmetricDifference(other).Equal(New("b", "d", "f", "g")))
+	assert.True(t, set.Contains(New("a", "b")))
+	assert.False(t, set.Contains(other))
+
+	// Snapshots are independent of the original set
+	snapshot := set.Snapshot()
+	set.Add("z")
+	assert.False(t, snapshot.Has("z"))
+}
+
+func TestSync_Concurrent(t *testing.T) {
+	set := NewSync[int]()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added int
+	)
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				n := set.AddAll(j)
+				mu.Lock()
+				added += n
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	assert.Equal(t, 100, added)
+	assert.Equal(t, 100, set.Len())
+}
+
+func TestSync_Marshalling(t *testing.T) {
+	set := NewSync("a", "b", "c", "d")
+
+	output, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	var fromJSON Sync[string]
+	require.NoError(t, json.Unmarshal(output, &fromJSON))
+	assert.True(t, fromJSON.Equal(New("a", "b", "c", "d")))
+
+	output, err = yaml.Marshal(set)
+	require.NoError(t, err)
+
+	var fromYAML Sync[string]
+	require.NoError(t, yaml.Unmarshal(output, &fromYAML))
+	assert.True(t, fromYAML.Equal(New("a", "b", "c", "d")))
+}
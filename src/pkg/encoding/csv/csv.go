@@ -0,0 +1,46 @@
+// Package csv converts Go values into CSV. Structs map to a single row,
+// with a column per field (nested structs are flattened into prefixed
+// columns), while slices, arrays and maps map to a row per element.
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Marshal returns the CSV encoding of v, including headers.
+func Marshal(v any, opts ...Option) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := Write(&buf, v, opts...); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Write writes the CSV encoding of v to w, including headers.
+func Write(w io.Writer, v any, opts ...Option) error {
+	if v == nil {
+		return fmt.Errorf("unable to marshal nil value")
+	}
+
+	m, err := NewMarshaller(reflect.TypeOf(v), opts...)
+	if err != nil {
+		return err
+	}
+
+	o := newOptions(opts)
+	csvw := NewWriter(w, o.writerOptions)
+	if err := csvw.Write(m.Headers()); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+
+	if err := m.Encode(csvw, v, o.nilValue); err != nil {
+		return err
+	}
+
+	csvw.Flush()
+	return csvw.Error()
+}
@@ -0,0 +1,158 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"reflect"
+)
+
+// An Encoder writes a stream of values as CSV rows, writing the headers
+// once before the first row. Unlike a Marshaller, an Encoder does not
+// need the entire collection up front, so it can be used for exports
+// that are too large to materialize in memory.
+//
+// Rows are buffered; callers must call Flush once all rows have been
+// encoded.
+type Encoder struct {
+	w             *Writer
+	typ           reflect.Type
+	mapper        RowMapper
+	proj          projection
+	headers       []string
+	nilValue      string
+	writeHeaders  bool
+	headerWritten bool
+}
+
+// NewEncoder creates an Encoder that writes rows of the given type.
+func NewEncoder(w io.Writer, typ reflect.Type, opts ...Option) (*Encoder, error) {
+	typ = derefType(typ)
+	o := newOptions(opts)
+	mapper, err := newRowMapper(typ, fieldTag{}, o)
+	if err != nil {
+		return nil, err
+	}
+
+	proj, err := newProjection(mapper.Headers(), o.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Encoder{
+		w:            NewWriter(w, o.writerOptions),
+		typ:          typ,
+		mapper:       mapper,
+		proj:         proj,
+		headers:      transformHeaders(proj.apply(mapper.Headers()), o.headerTransform),
+		nilValue:     o.nilValue,
+		writeHeaders: true,
+	}, nil
+}
+
+// SetNilValue sets the value written for nil fields.
+func (enc *Encoder) SetNilValue(nilValue string) {
+	enc.nilValue = nilValue
+}
+
+// SetWriteHeaders controls whether the headers are written before the first row.
+func (enc *Encoder) SetWriteHeaders(writeHeaders bool) {
+	enc.writeHeaders = writeHeaders
+}
+
+// Headers returns the headers for the rows written by the Encoder.
+func (enc *Encoder) Headers() []string {
+	return enc.headers
+}
+
+// EncodeRow writes a single value as a row (or as multiple rows, if the value
+// has an expanded field), writing the headers first if this is the first row.
+// Nil values are skipped.
+func (enc *Encoder) EncodeRow(v any) error {
+	if err := enc.maybeWriteHeaders(); err != nil {
+		return err
+	}
+
+	rv, ok := v.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(v)
+	}
+
+	if rv == zeroValue {
+		return nil
+	}
+
+	if typ := derefType(rv.Type()); typ != enc.typ {
+		return fmt.Errorf("cannot encode '%s' with Encoder for '%s'", typ, enc.typ)
+	}
+
+	rows, _, err := mapRows(enc.mapper, rv, enc.nilValue)
+	if err != nil {
+		return err
+	}
+
+	for _, values := range rows {
+		if err := enc.w.Write(enc.proj.apply(values)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying io.Writer. The headers
+// are written even if no rows were encoded.
+func (enc *Encoder) Flush() error {
+	if err := enc.maybeWriteHeaders(); err != nil {
+		return err
+	}
+
+	enc.w.Flush()
+	return enc.w.Error()
+}
+
+func (enc *Encoder) maybeWriteHeaders() error {
+	if enc.headerWritten || !enc.writeHeaders {
+		return nil
+	}
+
+	enc.headerWritten = true
+	if len(enc.headers) > 0 {
+		if err := enc.w.Write(enc.headers); err != nil {
+			return fmt.Errorf("unable to write header: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// EncodeSeq encodes every value produced by a sequence, stopping at the first error.
+func EncodeSeq[T any](enc *Encoder, seq iter.Seq[T]) error {
+	for v := range seq {
+		if err := enc.EncodeRow(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EncodeChan encodes every value received from a channel until the channel
+// is closed, stopping at the first error. On error, the channel is not drained.
+func EncodeChan[T any](enc *Encoder, ch <-chan T) error {
+	for v := range ch {
+		if err := enc.EncodeRow(v); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func derefType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
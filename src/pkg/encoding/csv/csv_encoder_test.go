@@ -0,0 +1,119 @@
+package csv
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var addresses = []*Address{
+	{
+		Street1: "209 W Houston St",
+		City:    "New York",
+		State:   "NY",
+		Zipcode: "10014",
+	},
+	{
+		Street1: "636 W 28th St",
+		City:    "New York",
+		State:   "NY",
+		Zipcode: "10001",
+	},
+	{
+		Street1: "375 W Broadway",
+		City:    "New York",
+		State:   "NY",
+		Zipcode: "10012",
+	},
+}
+
+func TestEncoder_EncodeRow(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Address{}))
+	require.NoError(t, err)
+
+	for _, addr := range addresses {
+		require.NoError(t, enc.EncodeRow(addr))
+	}
+
+	require.NoError(t, enc.EncodeRow(nil)) // skipped
+	require.NoError(t, enc.Flush())
+	assertCSVFilesMatch(t, "testdata/slice_of_structs.csv", buf.String(), false)
+}
+
+func TestEncoder_EncodeSeq(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(&Address{}))
+	require.NoError(t, err)
+
+	require.NoError(t, EncodeSeq(enc, slices.Values(addresses)))
+	require.NoError(t, enc.Flush())
+	assertCSVFilesMatch(t, "testdata/slice_of_structs.csv", buf.String(), false)
+}
+
+func TestEncoder_EncodeChan(t *testing.T) {
+	ch := make(chan Address)
+	go func() {
+		defer close(ch)
+		for _, addr := range addresses {
+			ch <- *addr
+		}
+	}()
+
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Address{}))
+	require.NoError(t, err)
+
+	require.NoError(t, EncodeChan(enc, ch))
+	require.NoError(t, enc.Flush())
+	assertCSVFilesMatch(t, "testdata/slice_of_structs.csv", buf.String(), false)
+}
+
+func TestEncoder_ExpandedFields(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Order{}), WithColumns("id", "item.sku"))
+	require.NoError(t, err)
+
+	require.NoError(t, enc.EncodeRow(Order{
+		ID:    1,
+		Items: []LineItem{{SKU: "A-100"}, {SKU: "B-200"}},
+	}))
+	require.NoError(t, enc.Flush())
+	assert.Equal(t, "id,item.sku\n1,A-100\n1,B-200\n", buf.String())
+}
+
+func TestEncoder_NoRows(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Address{}))
+	require.NoError(t, err)
+
+	require.NoError(t, enc.Flush())
+	assert.Equal(t, "Street1,City,State,Zipcode\n", buf.String())
+}
+
+func TestEncoder_NoHeaders(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Person{}))
+	require.NoError(t, err)
+
+	enc.SetWriteHeaders(false)
+	enc.SetNilValue("N/A")
+	require.NoError(t, enc.EncodeRow(Person{FirstName: "June", LastName: "Prune"}))
+	require.NoError(t, enc.Flush())
+	assert.Equal(t, "June,Prune,N/A,N/A,N/A,N/A\n", buf.String())
+}
+
+func TestEncoder_WrongType(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Address{}))
+	require.NoError(t, err)
+
+	err = enc.EncodeRow(Person{FirstName: "June", LastName: "Prune"})
+	if assert.Error(t, err) {
+		assert.Equal(t, "cannot encode 'csv.Person' with Encoder for 'csv.Address'", err.Error())
+	}
+}
@@ -0,0 +1,224 @@
+package csv
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+)
+
+// A GzipWriter writes gzip-compressed CSV rows. Callers must call Close
+// once all rows have been written.
+type GzipWriter struct {
+	*Writer
+	gz *gzip.Writer
+}
+
+// NewGzipWriter returns a new GzipWriter that writes to w.
+func NewGzipWriter(w io.Writer, opts WriterOptions) *GzipWriter {
+	gz := gzip.NewWriter(w)
+	return &GzipWriter{
+		Writer: NewWriter(gz, opts),
+		gz:     gz,
+	}
+}
+
+// Close flushes any buffered rows and finishes the gzip stream, even if
+// flushing fails. It does not close the underlying io.Writer.
+func (w *GzipWriter) Close() error {
+	w.Flush()
+	return errors.Join(w.Error(), w.gz.Close())
+}
+
+// SplitOptions control when a SplitWriter starts a new file.
+type SplitOptions struct {
+	// MaxRows is the maximum number of rows in each file, not counting the
+	// headers. Zero means no limit.
+	MaxRows int
+
+	// MaxBytes is the maximum size of each file, before compression. The size
+	// is estimated as rows are written, so should be set with some headroom
+	// if it is a hard limit. A file always holds at least one row, even if that
+	// row exceeds MaxBytes. Zero means no limit.
+	MaxBytes int64
+
+	// Gzip compresses each file.
+	Gzip bool
+}
+
+// A SplitWriter writes CSV rows across multiple files, starting a new file
+// whenever the current file reaches the limits set by its SplitOptions. The
+// headers are repeated at the start of each file. Callers must call Close
+// once all rows have been written.
+type SplitWriter struct {
+	create    func(n int) (io.WriteCloser, error)
+	headers   []string
+	opts      WriterOptions
+	splitOpts SplitOptions
+
+	file   io.WriteCloser
+	w      *Writer
+	finish func() error
+	files  int
+	rows   int
+	size   int64
+}
+
+// NewSplitWriter returns a new SplitWriter. Files are created on demand by
+// calling create with the index of the file, starting at 0. If headers is
+// nil, no headers are written.
+func NewSplitWriter(
+	create func(n int) (io.WriteCloser, error), headers []string, opts WriterOptions, splitOpts SplitOptions,
+) *SplitWriter {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+
+	return &SplitWriter{
+		create:    create,
+		headers:   headers,
+		opts:      opts,
+		splitOpts: splitOpts,
+	}
+}
+
+// Write writes a single row, starting a new file first if needed.
+func (w *SplitWriter) Write(record []string) error {
+	size := rowSize(record, w.opts)
+	if w.file == nil || w.full(size) {
+		if err := w.nextFile(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.w.Write(record); err != nil {
+		return err
+	}
+
+	w.rows++
+	w.size += size
+	return nil
+}
+
+// Files returns the number of files created so far.
+func (w *SplitWriter) Files() int {
+	return w.files
+}
+
+// Close flushes and closes the current file. If no rows were written, a
+// single file holding only the headers is created.
+func (w *SplitWriter) Close() error {
+	if w.files == 0 {
+		if err := w.nextFile(); err != nil {
+			return err
+		}
+	}
+
+	return w.closeFile()
+}
+
+func (w *SplitWriter) full(size int64) bool {
+	if w.splitOpts.MaxRows > 0 && w.rows >= w.splitOpts.MaxRows {
+		return true
+	}
+
+	return w.splitOpts.MaxBytes > 0 && w.rows > 0 && w.size+size > w.splitOpts.MaxBytes
+}
+
+func (w *SplitWriter) nextFile() error {
+	if err := w.closeFile(); err != nil {
+		return err
+	}
+
+	file, err := w.create(w.files)
+	if err != nil {
+		return fmt.Errorf("unable to create file %d: %w", w.files, err)
+	}
+
+	w.file, w.files, w.rows, w.size = file, w.files+1, 0, 0
+	if w.splitOpts.Gzip {
+		gw := NewGzipWriter(file, w.opts)
+		w.w, w.finish = gw.Writer, gw.Close
+	} else {
+		w.w = NewWriter(file, w.opts)
+		w.finish = func() error {
+			w.w.Flush()
+			return w.w.Error()
+		}
+	}
+
+	if w.headers == nil {
+		return nil
+	}
+
+	if err := w.w.Write(w.headers); err != nil {
+		return fmt.Errorf("unable to write header: %w", err)
+	}
+
+	w.size += rowSize(w.headers, w.opts)
+	return nil
+}
+
+func (w *SplitWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.finish()
+	err = errors.Join(err, w.file.Close())
+	w.file, w.w, w.finish = nil, nil, nil
+	return err
+}
+
+// rowSize estimates the number of bytes a row takes once written.
+func rowSize(record []string, opts WriterOptions) int64 {
+	size := int64(1)
+	if opts.UseCRLF {
+		size++
+	}
+
+	if len(record) > 1 {
+		size += int64((len(record) - 1) * utf8.RuneLen(opts.Comma))
+	}
+
+	for _, field := range record {
+		size += int64(len(field))
+		if opts.AlwaysQuote || strings.ContainsRune(field, opts.Comma) || strings.ContainsAny(field, "\"\r\n") {
+			size += 2 + int64(strings.Count(field, `"`))
+		}
+	}
+
+	return size
+}
+
+// CreateSplitFiles returns a function for use with NewSplitWriter that creates
+// numbered files based on the given path, inserting the 1-based file number
+// before the extension (e.g. my.export.csv becomes my.export-0001.csv,
+// my.export-0002.csv, and so on). A .gz extension is kept together with the
+// extension before it, so export.csv.gz becomes export-0001.csv.gz.
+func CreateSplitFiles(path string) func(n int) (io.WriteCloser, error) {
+	dir, base := filepath.Split(path)
+	ext := filepath.Ext(base)
+	if ext == ".gz" {
+		ext = filepath.Ext(strings.TrimSuffix(base, ext)) + ext
+	}
+
+	name := strings.TrimSuffix(base, ext)
+	if name == "" {
+		// A dotfile, e.g. .csv, is a name without an extension
+		name, ext = base, ""
+	}
+
+	return func(n int) (io.WriteCloser, error) {
+		return os.Create(filepath.Join(dir, fmt.Sprintf("%s-%04d%s", name, n+1, ext)))
+	}
+}
+
+var (
+	_ RowWriter = &GzipWriter{}
+	_ RowWriter = &SplitWriter{}
+)
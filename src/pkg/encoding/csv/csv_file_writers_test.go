@@ -0,0 +1,198 @@
+package csv
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzipWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewGzipWriter(&buf, WriterOptions{})
+	require.NoError(t, w.Write([]string{"Name", "City"}))
+	require.NoError(t, w.Write([]string{"Hanna Banana", "New York"}))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, "Name,City\nHanna Banana,New York\n", gunzip(t, buf.Bytes()))
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestGzipWriter_FlushError(t *testing.T) {
+	w := NewGzipWriter(failingWriter{}, WriterOptions{})
+	require.NoError(t, w.Write([]string{"Name", "City"}))
+	assert.ErrorContains(t, w.Close(), "disk full")
+}
+
+func TestSplitWriter(t *testing.T) {
+	headers := []string{"Name", "City"}
+	rows := [][]string{
+		{"Hanna Banana", "New York"},
+		{"June Prune", "Boston"},
+		{"Barry Cherry", "Chicago"},
+		{"Larry Berry", "Denver"},
+		{"Kerry Cherry", "Austin"},
+	}
+
+	for _, tt := range []struct {
+		name      string
+		headers   []string
+		rows      [][]string
+		splitOpts SplitOptions
+		expected  []string
+	}{
+		{
+			"no limits",
+			headers, rows,
+			SplitOptions{},
+			[]string{
+				"Name,City\nHanna Banana,New York\nJune Prune,Boston\nBarry Cherry,Chicago\n" +
+					"Larry Berry,Denver\nKerry Cherry,Austin\n",
+			},
+		},
+		{
+			"max rows",
+			headers, rows,
+			SplitOptions{MaxRows: 2},
+			[]string{
+				"Name,City\nHanna Banana,New York\nJune Prune,Boston\n",
+				"Name,City\nBarry Cherry,Chicago\nLarry Berry,Denver\n",
+				"Name,City\nKerry Cherry,Austin\n",
+			},
+		},
+		{
+			"max bytes",
+			headers, rows,
+			SplitOptions{MaxBytes: 49},
+			[]string{
+				"Name,City\nHanna Banana,New York\n",
+				"Name,City\nJune Prune,Boston\nBarry Cherry,Chicago\n",
+				"Name,City\nLarry Berry,Denver\nKerry Cherry,Austin\n",
+			},
+		},
+		{
+			"row larger than max bytes",
+			headers, rows[:2],
+			SplitOptions{MaxBytes: 10},
+			[]string{
+				"Name,City\nHanna Banana,New York\n",
+				"Name,City\nJune Prune,Boston\n",
+			},
+		},
+		{
+			"no headers",
+			nil, rows[:3],
+			SplitOptions{MaxRows: 2},
+			[]string{
+				"Hanna Banana,New York\nJune Prune,Boston\n",
+				"Barry Cherry,Chicago\n",
+			},
+		},
+		{
+			"no rows",
+			headers, nil,
+			SplitOptions{MaxRows: 2},
+			[]string{
+				"Name,City\n",
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var files []*closingBuffer
+			w := NewSplitWriter(func(n int) (io.WriteCloser, error) {
+				require.Equal(t, len(files), n)
+				files = append(files, &closingBuffer{})
+				return files[n], nil
+			}, tt.headers, WriterOptions{}, tt.splitOpts)
+
+			for _, row := range tt.rows {
+				require.NoError(t, w.Write(row))
+			}
+
+			require.NoError(t, w.Close())
+			assert.Equal(t, len(tt.expected), w.Files())
+
+			actual := make([]string, 0, len(files))
+			for _, f := range files {
+				assert.True(t, f.closed)
+				actual = append(actual, f.String())
+			}
+
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestSplitWriter_CreateSplitFiles(t *testing.T) {
+	dir := t.TempDir()
+	w := NewSplitWriter(CreateSplitFiles(filepath.Join(dir, "export.csv.gz")),
+		[]string{"Name"}, WriterOptions{}, SplitOptions{MaxRows: 1, Gzip: true})
+
+	require.NoError(t, w.Write([]string{"Hanna Banana"}))
+	require.NoError(t, w.Write([]string{"June Prune"}))
+	require.NoError(t, w.Close())
+
+	for _, tt := range []struct {
+		filename string
+		expected string
+	}{
+		{"export-0001.csv.gz", "Name\nHanna Banana\n"},
+		{"export-0002.csv.gz", "Name\nJune Prune\n"},
+	} {
+		data, err := os.ReadFile(filepath.Join(dir, tt.filename))
+		require.NoError(t, err)
+		assert.Equal(t, tt.expected, gunzip(t, data), tt.filename)
+	}
+}
+
+func TestCreateSplitFiles_Names(t *testing.T) {
+	for _, tt := range []struct {
+		path     string
+		expected string
+	}{
+		{"export.csv", "export-0001.csv"},
+		{"export.csv.gz", "export-0001.csv.gz"},
+		{"my.report.csv", "my.report-0001.csv"},
+		{"my.report.csv.gz", "my.report-0001.csv.gz"},
+		{"export", "export-0001"},
+		{".csv", ".csv-0001"},
+	} {
+		t.Run(tt.path, func(t *testing.T) {
+			dir := t.TempDir()
+			f, err := CreateSplitFiles(filepath.Join(dir, tt.path))(0)
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+
+			_, err = os.Stat(filepath.Join(dir, tt.expected))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+type closingBuffer struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *closingBuffer) Close() error {
+	b.closed = true
+	return nil
+}
+
+func gunzip(t *testing.T, data []byte) string {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
@@ -0,0 +1,109 @@
+package csv
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a header to snake_case, e.g. MailingAddress.ZipCode
+// becomes mailing_address.zip_code. Runs of capitals are treated as a
+// single word, so HTTPStatus becomes http_status.
+func SnakeCase(header string) string {
+	return toSnakeCase(header, unicode.ToLower)
+}
+
+// ScreamingSnakeCase converts a header to SCREAMING_SNAKE_CASE, e.g.
+// MailingAddress.ZipCode becomes MAILING_ADDRESS.ZIP_CODE.
+func ScreamingSnakeCase(header string) string {
+	return toSnakeCase(header, unicode.ToUpper)
+}
+
+func toSnakeCase(s string, toCase func(rune) rune) string {
+	runes := []rune(s)
+
+	var sb strings.Builder
+	sb.Grow(len(s) + 4)
+	for i, r := range runes {
+		if r == ' ' || r == '-' {
+			r = '_'
+		}
+
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				sb.WriteRune('_')
+			}
+		}
+
+		sb.WriteRune(toCase(r))
+	}
+
+	return sb.String()
+}
+
+func transformHeaders(headers []string, transform func(string) string) []string {
+	if transform == nil {
+		return headers
+	}
+
+	transformed := make([]string, len(headers))
+	for i, header := range headers {
+		transformed[i] = transform(header)
+	}
+
+	return transformed
+}
+
+// A projection selects and orders a subset of the columns in a row, holding
+// the index of each selected column. A nil projection selects all columns.
+type projection []int
+
+func newProjection(headers, columns []string) (projection, error) {
+	if columns == nil {
+		return nil, nil
+	}
+
+	indexes := make(map[string]int, len(headers))
+	for i, header := range headers {
+		indexes[header] = i
+	}
+
+	proj := make(projection, 0, len(columns))
+	for _, column := range columns {
+		i, ok := indexes[column]
+		if !ok {
+			return nil, fmt.Errorf("unknown column '%s'", column)
+		}
+
+		proj = append(proj, i)
+	}
+
+	return proj, nil
+}
+
+func (proj projection) apply(values []string) []string {
+	if proj == nil {
+		return values
+	}
+
+	projected := make([]string, len(proj))
+	for i, n := range proj {
+		if n < len(values) {
+			projected[i] = values[n]
+		}
+	}
+
+	return projected
+}
+
+// A projectingWriter is a RowWriter that applies a projection to each row.
+type projectingWriter struct {
+	w    RowWriter
+	proj projection
+}
+
+func (w *projectingWriter) Write(record []string) error {
+	return w.w.Write(w.proj.apply(record))
+}
@@ -0,0 +1,103 @@
+package csv
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeCase(t *testing.T) {
+	for _, tt := range []struct {
+		header           string
+		snake, screaming string
+	}{
+		{"FirstName", "first_name", "FIRST_NAME"},
+		{"MailingAddress.Zipcode", "mailing_address.zipcode", "MAILING_ADDRESS.ZIPCODE"},
+		{"MailingAddress.ZipCode", "mailing_address.zip_code", "MAILING_ADDRESS.ZIP_CODE"},
+		{"HTTPStatus", "http_status", "HTTP_STATUS"},
+		{"UserID", "user_id", "USER_ID"},
+		{"Street1", "street1", "STREET1"},
+		{"Line2Text", "line2_text", "LINE2_TEXT"},
+		{"Tags.0", "tags.0", "TAGS.0"},
+		{"already_snake", "already_snake", "ALREADY_SNAKE"},
+		{"mailing address", "mailing_address", "MAILING_ADDRESS"},
+	} {
+		t.Run(tt.header, func(t *testing.T) {
+			assert.Equal(t, tt.snake, SnakeCase(tt.header))
+			assert.Equal(t, tt.screaming, ScreamingSnakeCase(tt.header))
+		})
+	}
+}
+
+func TestWithHeaderTransform(t *testing.T) {
+	m, err := NewMarshaller(reflect.TypeOf([]Person{}), WithHeaderTransform(SnakeCase))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"first_name", "last_name",
+		"mailing_address.street1", "mailing_address.city",
+		"mailing_address.state", "mailing_address.zipcode",
+	}, m.Headers())
+
+	b, err := Marshal(addresses[:1], WithHeaderTransform(strings.ToLower))
+	require.NoError(t, err)
+	assert.Equal(t, "street1,city,state,zipcode\n209 W Houston St,New York,NY,10014\n", string(b))
+
+	enc, err := NewEncoder(nil, reflect.TypeOf(Address{}), WithHeaderTransform(ScreamingSnakeCase))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"STREET1", "CITY", "STATE", "ZIPCODE"}, enc.Headers())
+}
+
+func TestWithColumns(t *testing.T) {
+	people := []Person{
+		{
+			FirstName: "Hanna",
+			LastName:  "Banana",
+			MailingAddress: &Address{
+				Street1: "209 W Houston St",
+				City:    "New York",
+				State:   "NY",
+				Zipcode: "10014",
+			},
+		},
+		{
+			FirstName: "June",
+			LastName:  "Prune",
+		},
+	}
+
+	b, err := Marshal(people, WithColumns("MailingAddress.City", "LastName", "MailingAddress.State"))
+	require.NoError(t, err)
+	assert.Equal(t, "MailingAddress.City,LastName,MailingAddress.State\n"+
+		"New York,Banana,NY\n"+
+		",Prune,\n", string(b))
+
+	b, err = Marshal(people,
+		WithColumns("LastName", "MailingAddress.Zipcode"),
+		WithHeaderTransform(SnakeCase),
+		WithNilValue("N/A"))
+	require.NoError(t, err)
+	assert.Equal(t, "last_name,mailing_address.zipcode\n"+
+		"Banana,10014\n"+
+		"Prune,N/A\n", string(b))
+
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Person{}), WithColumns("LastName", "FirstName"))
+	require.NoError(t, err)
+	for _, p := range people {
+		require.NoError(t, enc.EncodeRow(p))
+	}
+	require.NoError(t, enc.Flush())
+	assert.Equal(t, "LastName,FirstName\nBanana,Hanna\nPrune,June\n", buf.String())
+}
+
+func TestWithColumns_UnknownColumn(t *testing.T) {
+	_, err := NewMarshaller(reflect.TypeOf([]Person{}), WithColumns("LastName", "MailingAddress.Country"))
+	assert.EqualError(t, err, "unknown column 'MailingAddress.Country'")
+
+	_, err = NewEncoder(nil, reflect.TypeOf(Person{}), WithColumns("Surname"))
+	assert.EqualError(t, err, "unknown column 'Surname'")
+}
@@ -0,0 +1,180 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// newListFieldMapper creates a RowMapper for a struct field that is a slice
+// or array. By default, the elements are joined into a single column with
+// a separator, which can be changed with the sep= tag option. If the field
+// has a max= tag option, the elements are instead written to indexed columns
+// (e.g. Tags.0, Tags.1, Tags.2 for max=3). If the field has an expand tag
+// option, the containing struct is instead written as a row per element, with
+// the other columns repeated on each row.
+func newListFieldMapper(typ reflect.Type, tag fieldTag, opts *options) (RowMapper, error) {
+	elemMapper, err := newRowMapper(typ.Elem(), tag, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := tag.opts["expand"]; ok {
+		return &expandedListRowMapper{
+			name:       tag.name,
+			elemMapper: elemMapper,
+		}, nil
+	}
+
+	if len(elemMapper.Headers()) != 0 {
+		return nil, fmt.Errorf("cannot flatten '%s' for column '%s': elements must convert to a single column",
+			typ.String(), tag.name)
+	}
+
+	maxElems, ok := tag.opts["max"]
+	if !ok {
+		sep := opts.listSeparator
+		if tagSep, ok := tag.opts["sep"]; ok {
+			sep = tagSep
+		}
+
+		return &delimitedListRowMapper{
+			elemMapper: elemMapper,
+			sep:        sep,
+		}, nil
+	}
+
+	n, err := strconv.Atoi(maxElems)
+	if err != nil || n <= 0 {
+		return nil, fmt.Errorf("invalid max '%s' for column '%s'", maxElems, tag.name)
+	}
+
+	headers := make([]string, n)
+	for i := range headers {
+		headers[i] = strconv.Itoa(i)
+	}
+
+	return &indexedListRowMapper{
+		name:       tag.name,
+		headers:    headers,
+		elemMapper: elemMapper,
+	}, nil
+}
+
+// delimitedListRowMapper maps a slice or array to a single column, joining
+// the elements with a separator.
+type delimitedListRowMapper struct {
+	elemMapper RowMapper
+	sep        string
+}
+
+func (c *delimitedListRowMapper) Headers() []string { return nil }
+func (c *delimitedListRowMapper) Values(val reflect.Value, nilValue string) ([]string, bool, error) {
+	val = deref(val)
+
+	elems, err := listElemValues(c.elemMapper, val, nilValue)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return []string{strings.Join(elems, c.sep)}, true, nil
+}
+
+// indexedListRowMapper maps a slice or array to a fixed number of indexed
+// columns. Columns beyond the length of the slice hold the nil value.
+type indexedListRowMapper struct {
+	name       string
+	headers    []string
+	elemMapper RowMapper
+}
+
+func (c *indexedListRowMapper) Headers() []string { return c.headers }
+func (c *indexedListRowMapper) Values(val reflect.Value, nilValue string) ([]string, bool, error) {
+	val = deref(val)
+	if val.Len() > len(c.headers) {
+		return nil, false, fmt.Errorf("column '%s' has %d elements, more than the max of %d",
+			c.name, val.Len(), len(c.headers))
+	}
+
+	elems, err := listElemValues(c.elemMapper, val, nilValue)
+	if err != nil {
+		return nil, false, err
+	}
+
+	for len(elems) < len(c.headers) {
+		elems = append(elems, nilValue)
+	}
+
+	return elems, true, nil
+}
+
+func (c *indexedListRowMapper) nilValues(nilValue string) []string {
+	return repeat(nilValue, len(c.headers))
+}
+
+// expandedListRowMapper maps a slice or array to a row per element. An empty
+// or nil slice maps to a single row of nil values, so that the containing
+// struct is still written.
+type expandedListRowMapper struct {
+	name       string
+	elemMapper RowMapper
+}
+
+func (c *expandedListRowMapper) Headers() []string { return c.elemMapper.Headers() }
+func (c *expandedListRowMapper) Values(_ reflect.Value, _ string) ([]string, bool, error) {
+	return nil, false, fmt.Errorf("column '%s' expands to multiple rows", c.name)
+}
+
+func (c *expandedListRowMapper) Rows(val reflect.Value, nilValue string) ([][]string, bool, error) {
+	val = deref(val)
+	if val.Len() == 0 {
+		return [][]string{c.nilValues(nilValue)}, true, nil
+	}
+
+	rows := make([][]string, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		if isNillable(elem.Kind()) && elem.IsNil() {
+			rows = append(rows, c.nilValues(nilValue))
+			continue
+		}
+
+		elemRows, processElem, err := mapRows(c.elemMapper, elem, nilValue)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to convert element %d: %w", i, err)
+		}
+
+		if processElem {
+			rows = append(rows, elemRows...)
+		}
+	}
+
+	return rows, len(rows) != 0, nil
+}
+
+func (c *expandedListRowMapper) nilValues(nilValue string) []string {
+	return repeat(nilValue, max(len(c.Headers()), 1))
+}
+
+func listElemValues(elemMapper RowMapper, val reflect.Value, nilValue string) ([]string, error) {
+	elems := make([]string, 0, val.Len())
+	for i := 0; i < val.Len(); i++ {
+		elem := val.Index(i)
+		if isNillable(elem.Kind()) && elem.IsNil() {
+			elems = append(elems, nilValue)
+			continue
+		}
+
+		elemValues, processElem, err := elemMapper.Values(elem, nilValue)
+		if err != nil {
+			return nil, fmt.Errorf("unable to convert element %d: %w", i, err)
+		}
+
+		if processElem {
+			elems = append(elems, elemValues...)
+		}
+	}
+
+	return elems, nil
+}
@@ -0,0 +1,86 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// newMapFieldMapper creates a RowMapper for a struct field that is a map with
+// string keys. Since the headers must be known up front, the field must declare
+// the keys to write with the keys= tag option, separated by "|", e.g.
+// `csv:"labels,keys=env|team|region"`. Each key becomes a column prefixed
+// with the field name (e.g. labels.env), and keys not present in the map hold
+// the nil value. Keys not declared in the tag are ignored.
+func newMapFieldMapper(typ reflect.Type, tag fieldTag, opts *options) (RowMapper, error) {
+	if typ.Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("cannot convert '%s' for column '%s': map keys must be strings",
+			typ.String(), tag.name)
+	}
+
+	keys, ok := tag.opts["keys"]
+	if !ok || keys == "" {
+		return nil, fmt.Errorf("cannot convert '%s' for column '%s': map fields require a keys= tag option",
+			typ.String(), tag.name)
+	}
+
+	valMapper, err := newRowMapper(typ.Elem(), tag, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(valMapper.Headers()) != 0 {
+		return nil, fmt.Errorf("cannot convert '%s' for column '%s': values must convert to a single column",
+			typ.String(), tag.name)
+	}
+
+	headers := strings.Split(keys, "|")
+	mapKeys := make([]reflect.Value, len(headers))
+	for i, key := range headers {
+		mapKeys[i] = reflect.ValueOf(key).Convert(typ.Key())
+	}
+
+	return &mapFieldRowMapper{
+		headers:   headers,
+		keys:      mapKeys,
+		valMapper: valMapper,
+	}, nil
+}
+
+// mapFieldRowMapper maps a map to a column per declared key.
+type mapFieldRowMapper struct {
+	headers   []string
+	keys      []reflect.Value
+	valMapper RowMapper
+}
+
+func (c *mapFieldRowMapper) Headers() []string { return c.headers }
+func (c *mapFieldRowMapper) Values(val reflect.Value, nilValue string) ([]string, bool, error) {
+	val = deref(val)
+
+	values := make([]string, 0, len(c.keys))
+	for _, key := range c.keys {
+		elem := val.MapIndex(key)
+		if elem == zeroValue || (isNillable(elem.Kind()) && elem.IsNil()) {
+			values = append(values, nilValue)
+			continue
+		}
+
+		elemValues, processElem, err := c.valMapper.Values(elem, nilValue)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to convert key '%s': %w", key, err)
+		}
+
+		if processElem {
+			values = append(values, elemValues...)
+		} else {
+			values = append(values, nilValue)
+		}
+	}
+
+	return values, true, nil
+}
+
+func (c *mapFieldRowMapper) nilValues(nilValue string) []string {
+	return repeat(nilValue, len(c.headers))
+}
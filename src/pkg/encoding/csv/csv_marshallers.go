@@ -1,45 +1,91 @@
 package csv
 
 import (
-	"encoding/csv"
+	"cmp"
 	"fmt"
 	"reflect"
+	"slices"
+	"sync"
 )
 
-// A Marshaller marshals objects into CSV format.
+// A Marshaller marshals objects into CSV format. Encode writes the rows for
+// a value to any RowWriter, including the standard library *csv.Writer.
 type Marshaller interface {
 	Headers() []string
-	Encode(w *csv.Writer, val any, nilValue string) error
+	Encode(w RowWriter, val any, nilValue string) error
 }
 
 // NewMarshaller creates a marshaller for the given type.
-func NewMarshaller(typ reflect.Type) (Marshaller, error) {
+func NewMarshaller(typ reflect.Type, opts ...Option) (Marshaller, error) {
+	o := newOptions(opts)
+	m, err := newMarshaller(typ, o)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.headerTransform == nil && o.columns == nil {
+		return m, nil
+	}
+
+	proj, err := newProjection(m.Headers(), o.columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &configuredMarshaller{
+		Marshaller: m,
+		headers:    transformHeaders(proj.apply(m.Headers()), o.headerTransform),
+		proj:       proj,
+	}, nil
+}
+
+func newMarshaller(typ reflect.Type, opts *options) (Marshaller, error) {
 	for typ.Kind() == reflect.Ptr {
 		typ = typ.Elem()
 	}
 
 	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
-		return newSliceMarshaller(typ)
+		return newSliceMarshaller(typ, opts)
 	}
 
 	if typ.Kind() == reflect.Map {
-		return newMapMarshaller(typ)
+		return newMapMarshaller(typ, opts)
 	}
 
 	if typ.Kind() == reflect.Struct {
-		return newSingleRowMarshaller(typ)
+		return newSingleRowMarshaller(typ, opts)
 	}
 
 	return nil, fmt.Errorf("unable to create Marshaller for '%s'", typ.Name())
 }
 
+// A configuredMarshaller is a Marshaller with selected columns and
+// transformed headers.
+type configuredMarshaller struct {
+	Marshaller
+	headers []string
+	proj    projection
+}
+
+func (m *configuredMarshaller) Headers() []string {
+	return m.headers
+}
+
+func (m *configuredMarshaller) Encode(w RowWriter, val any, nilValue string) error {
+	if m.proj == nil {
+		return m.Marshaller.Encode(w, val, nilValue)
+	}
+
+	return m.Marshaller.Encode(&projectingWriter{w: w, proj: m.proj}, val, nilValue)
+}
+
 // singleRowMarshaller is a Marshaller for a single struct.
 type singleRowMarshaller struct {
 	rowMapper RowMapper
 }
 
-func newSingleRowMarshaller(typ reflect.Type) (Marshaller, error) {
-	rowMapper, err := newStructMapper(typ)
+func newSingleRowMarshaller(typ reflect.Type, opts *options) (Marshaller, error) {
+	rowMapper, err := newStructMapper(typ, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -53,36 +99,36 @@ func (m *singleRowMarshaller) Headers() []string {
 	return m.rowMapper.Headers()
 }
 
-func (m *singleRowMarshaller) Encode(w *csv.Writer, val any, nilValue string) error {
-	rv, ok := val.(reflect.Value)
-	if !ok {
-		rv = reflect.ValueOf(val)
-	}
+func (m *singleRowMarshaller) Encode(w RowWriter, val any, nilValue string) error {
+	rv := valueOf(val)
 
-	if values, processRow := m.rowMapper.Values(rv, nilValue); processRow {
-		return w.Write(values)
+	rows, _, err := mapRows(m.rowMapper, rv, nilValue)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return writeRows(w, rows)
 }
 
 // A sliceMarshaller is a Marshaller for slices of structs or slices of primitives.
 type sliceMarshaller struct {
-	elemMapper RowMapper
+	elemMapper  RowMapper
+	parallelism int
 }
 
-func newSliceMarshaller(typ reflect.Type) (Marshaller, error) {
+func newSliceMarshaller(typ reflect.Type, opts *options) (Marshaller, error) {
 	if typ.Kind() != reflect.Slice && typ.Kind() != reflect.Array {
 		return nil, fmt.Errorf("cannot create slice Marshaller for '%s'", typ.String())
 	}
 
-	elemMapper, err := newRowMapper(typ.Elem())
+	elemMapper, err := newRowMapper(typ.Elem(), fieldTag{}, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	return &sliceMarshaller{
-		elemMapper: elemMapper,
+		elemMapper:  elemMapper,
+		parallelism: opts.parallelism,
 	}, nil
 }
 
@@ -90,35 +136,142 @@ func (m *sliceMarshaller) Headers() []string {
 	return m.elemMapper.Headers()
 }
 
-func (m *sliceMarshaller) Encode(w *csv.Writer, val any, nilValue string) error {
-	rv, ok := val.(reflect.Value)
-	if !ok {
-		rv = reflect.ValueOf(val)
+func (m *sliceMarshaller) Encode(w RowWriter, val any, nilValue string) error {
+	rv := valueOf(val)
+	if !rv.IsValid() {
+		// A nil pointer has no rows
+		return nil
+	}
+
+	if m.parallelism > 1 && rv.Len() > parallelBatchSize {
+		return m.encodeParallel(w, rv, nilValue)
 	}
 
 	for i := 0; i < rv.Len(); i++ {
 		elem := rv.Index(i)
 
-		if values, processRow := m.elemMapper.Values(elem, nilValue); processRow {
-			if err := w.Write(values); err != nil {
-				return err
-			}
+		rows, _, err := mapRows(m.elemMapper, elem, nilValue)
+		if err != nil {
+			return err
+		}
+
+		if err := writeRows(w, rows); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// parallelBatchSize is the number of elements converted at a time by each
+// worker when marshalling in parallel.
+const parallelBatchSize = 256
+
+// A rowBatch is a range of slice elements converted to rows by a worker.
+type rowBatch struct {
+	start, end int
+	rows       [][]string
+	err        error
+	done       chan struct{}
+}
+
+// encodeParallel converts batches of elements to rows across a pool of
+// workers. Batches are queued for writing in order as they are handed to
+// the workers, so rows are written in the same order as the elements.
+func (m *sliceMarshaller) encodeParallel(w RowWriter, rv reflect.Value, nilValue string) error {
+	pending := make(chan *rowBatch, m.parallelism)
+	work := make(chan *rowBatch)
+	stop := make(chan struct{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < m.parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for b := range work {
+				b.rows, b.err = m.mapBatch(rv, b.start, b.end, nilValue)
+				close(b.done)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(pending)
+		defer close(work)
+
+		for start := 0; start < rv.Len(); start += parallelBatchSize {
+			b := &rowBatch{
+				start: start,
+				end:   min(start+parallelBatchSize, rv.Len()),
+				done:  make(chan struct{}),
+			}
+
+			select {
+			case <-stop:
+				return
+			default:
+			}
+
+			select {
+			case pending <- b:
+			case <-stop:
+				return
+			}
+
+			work <- b
+		}
+	}()
+
+	var err error
+	for b := range pending {
+		<-b.done
+		if err = b.err; err == nil {
+			err = writeRows(w, b.rows)
+		}
+
+		if err != nil {
+			close(stop)
+			break
+		}
+	}
+
+	// Drain any remaining batches so the workers can finish
+	for range pending {
+	}
+
+	wg.Wait()
+	return err
+}
+
+func (m *sliceMarshaller) mapBatch(rv reflect.Value, start, end int, nilValue string) ([][]string, error) {
+	batch := make([][]string, 0, end-start)
+	for i := start; i < end; i++ {
+		rows, _, err := mapRows(m.elemMapper, rv.Index(i), nilValue)
+		if err != nil {
+			return nil, err
+		}
+
+		batch = append(batch, rows...)
+	}
+
+	return batch, nil
+}
+
 // A mapMarshaller is a Marshaller for maps of primitives or maps of structs.
 type mapMarshaller struct {
 	headers   []string
 	keyMapper RowMapper
 	valMapper RowMapper
+	keyCmp    func(a, b any) int
 }
 
-func newMapMarshaller(typ reflect.Type) (Marshaller, error) {
-	keyMapper := &primitiveRowMapper{}
-	valMapper, err := newRowMapper(typ.Elem())
+func newMapMarshaller(typ reflect.Type, opts *options) (Marshaller, error) {
+	keyMapper, err := newPrimitiveRowMapper(typ.Key(), fieldTag{}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	valMapper, err := newRowMapper(typ.Elem(), fieldTag{}, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -136,32 +289,67 @@ func newMapMarshaller(typ reflect.Type) (Marshaller, error) {
 		headers:   headers,
 		keyMapper: keyMapper,
 		valMapper: valMapper,
+		keyCmp:    opts.mapKeyCmp,
 	}, nil
 }
 
-func (m *mapMarshaller) Encode(w *csv.Writer, val any, nilValue string) error {
-	rv, ok := val.(reflect.Value)
-	if !ok {
-		rv = reflect.ValueOf(val)
+func (m *mapMarshaller) Encode(w RowWriter, val any, nilValue string) error {
+	rv := valueOf(val)
+	if !rv.IsValid() {
+		// A nil pointer has no rows
+		return nil
 	}
 
-	iter := rv.MapRange()
-	for iter.Next() {
-		key, val := iter.Key(), iter.Value()
-		rowValues := make([]string, 0, len(m.headers))
+	if m.keyCmp == nil {
+		iter := rv.MapRange()
+		for iter.Next() {
+			if err := m.encodeRow(w, iter.Key(), iter.Value(), nilValue); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
 
-		if keyValues, processKey := m.keyMapper.Values(key, nilValue); processKey {
-			rowValues = append(rowValues, keyValues...)
+	keys := rv.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		return m.keyCmp(a.Interface(), b.Interface())
+	})
+
+	for _, key := range keys {
+		if err := m.encodeRow(w, key, rv.MapIndex(key), nilValue); err != nil {
+			return err
 		}
+	}
 
-		if valValues, processVal := m.valMapper.Values(val, nilValue); processVal {
-			rowValues = append(rowValues, valValues...)
+	return nil
+}
+
+func (m *mapMarshaller) encodeRow(w RowWriter, key, val reflect.Value, nilValue string) error {
+	keyValues, _, err := m.keyMapper.Values(key, nilValue)
+	if err != nil {
+		return err
+	}
+
+	valRows, processVal, err := mapRows(m.valMapper, val, nilValue)
+	if err != nil {
+		return err
+	}
+
+	if !processVal {
+		valRows = [][]string{nil}
+	}
+
+	for _, valValues := range valRows {
+		rowValues := make([]string, 0, len(m.headers))
+		rowValues = append(rowValues, keyValues...)
+		rowValues = append(rowValues, valValues...)
+		if len(rowValues) == 0 {
+			continue
 		}
 
-		if len(rowValues) != 0 {
-			if err := w.Write(rowValues); err != nil {
-				return err
-			}
+		if err := w.Write(rowValues); err != nil {
+			return err
 		}
 	}
 
@@ -171,3 +359,51 @@ func (m *mapMarshaller) Encode(w *csv.Writer, val any, nilValue string) error {
 func (m *mapMarshaller) Headers() []string {
 	return m.headers
 }
+
+// valueOf returns the value to encode, which may already be a reflect.Value,
+// following any pointers. A nil pointer returns the zero reflect.Value.
+func valueOf(val any) reflect.Value {
+	rv, ok := val.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(val)
+	}
+
+	return deref(rv)
+}
+
+func writeRows(w RowWriter, rows [][]string) error {
+	for _, row := range rows {
+		if err := w.Write(row); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// compareKeys compares two map keys in their natural order. Keys of
+// differing or non-primitive types are compared by their string form.
+func compareKeys(a, b any) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() {
+		switch {
+		case va.CanInt():
+			return cmp.Compare(va.Int(), vb.Int())
+		case va.CanUint():
+			return cmp.Compare(va.Uint(), vb.Uint())
+		case va.CanFloat():
+			return cmp.Compare(va.Float(), vb.Float())
+		case va.Kind() == reflect.String:
+			return cmp.Compare(va.String(), vb.String())
+		case va.Kind() == reflect.Bool:
+			if va.Bool() == vb.Bool() {
+				return 0
+			} else if vb.Bool() {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
@@ -4,16 +4,20 @@ import (
 	"bufio"
 	"bytes"
 	"encoding/csv"
+	"fmt"
 	"io"
 	"math"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mmihic/golib/src/pkg/ptr"
+	"github.com/mmihic/golib/src/pkg/timex"
 )
 
 const writeFiles = false
@@ -53,6 +57,53 @@ type PlaceWithTags struct {
 	SecondaryAddress AddressWithTags `csv:"-"`
 }
 
+type AddressWithOrder struct {
+	Street1 string `csv:"street_1,order=2"`
+	City    string `csv:"city,order=3"`
+	State   string `csv:"state"`
+	Zipcode string `csv:"zip_code,order=1"`
+	Country string
+}
+
+type Priority int
+
+func (p Priority) String() string {
+	switch p {
+	case 1:
+		return "high"
+	case 2:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
+
+type Coordinates struct {
+	Lat, Lng float64
+}
+
+func (c *Coordinates) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%.4f:%.4f", c.Lat, c.Lng)), nil
+}
+
+type Event struct {
+	Name     string
+	Priority Priority
+	Location Coordinates
+	On       timex.Date
+	Starts   time.Time
+	Ends     *time.Time `csv:"ends,format=2006-01-02 15:04"`
+}
+
+type Article struct {
+	Title    string
+	Authors  []string
+	Keywords []string   `csv:"keywords,sep=|"`
+	Tags     []string   `csv:"tags,max=3"`
+	Scores   [2]float64 `csv:"scores,max=2,precision=1"`
+	Ratings  []*int
+}
+
 func TestMarshaller(t *testing.T) {
 	for _, tt := range []struct {
 		name     string
@@ -91,6 +142,18 @@ func TestMarshaller(t *testing.T) {
 			"testdata/struct_with_tags.csv",
 			false,
 		},
+		{
+			"struct with column order",
+			AddressWithOrder{
+				Street1: "209 W Houston St",
+				City:    "New York",
+				State:   "NY",
+				Zipcode: "10014",
+				Country: "USA",
+			},
+			"testdata/struct_with_column_order.csv",
+			false,
+		},
 		{
 			"embedded ptr to struct",
 			Person{
@@ -120,6 +183,47 @@ func TestMarshaller(t *testing.T) {
 			"testdata/anonymous_struct.csv",
 			false,
 		},
+		{
+			"struct with text fields",
+			[]Event{
+				{
+					Name:     "Matinee",
+					Priority: 1,
+					Location: Coordinates{Lat: 40.7282, Lng: -74.0047},
+					On:       timex.MustParseDate("2023-10-14"),
+					Starts:   time.Date(2023, time.October, 14, 13, 30, 0, 0, time.UTC),
+					Ends:     ptr.To(time.Date(2023, time.October, 14, 15, 45, 0, 0, time.UTC)),
+				},
+				{
+					Name:     "Late Show",
+					Priority: 2,
+					Location: Coordinates{Lat: 40.7282, Lng: -74.0047},
+					On:       timex.MustParseDate("2023-10-15"),
+					Starts:   time.Date(2023, time.October, 15, 22, 0, 0, 0, time.UTC),
+				},
+			},
+			"testdata/struct_with_text_fields.csv",
+			false,
+		},
+		{
+			"struct with list fields",
+			[]Article{
+				{
+					Title:    "Generics in Go",
+					Authors:  []string{"Hanna Banana", "June Prune"},
+					Keywords: []string{"go", "generics"},
+					Tags:     []string{"programming", "languages"},
+					Scores:   [2]float64{4.25, 3.5},
+					Ratings:  []*int{ptr.To(4), nil, ptr.To(5)},
+				},
+				{
+					Title:   "Untagged",
+					Authors: []string{"Barry Cherry"},
+				},
+			},
+			"testdata/struct_with_list_fields.csv",
+			false,
+		},
 		{
 			"slice of structs",
 			[]Address{
@@ -287,6 +391,403 @@ func TestMarshaller(t *testing.T) {
 	}
 }
 
+type Money struct {
+	Cents    int64
+	Currency string
+}
+
+type Invoice struct {
+	Number   string
+	Customer string `csv:"customer,marshaller=upper"`
+	Total    Money
+	Tax      *Money
+}
+
+func TestMarshaller_SortedMapKeys(t *testing.T) {
+	assertMarshalMatches(t, map[string]*Address{
+		"Hanna Banana": {
+			Street1: "209 W Houston St",
+			City:    "New York",
+			State:   "NY",
+			Zipcode: "10014",
+		},
+		"June Prune": {
+			Street1: "636 W 28th St",
+			City:    "New York",
+			State:   "NY",
+			Zipcode: "10001",
+		},
+		"Barry Cherry": {
+			Street1: "375 W Broadway",
+			City:    "New York",
+			State:   "NY",
+			Zipcode: "10012",
+		},
+	}, "testdata/sorted_map_of_ptr_to_structs.csv", false, WithSortedMapKeys())
+
+	assertMarshalMatches(t, map[int]string{
+		10: "ten",
+		2:  "two",
+		-4: "minus four",
+		35: "thirty five",
+	}, "testdata/sorted_map_of_int_keys.csv", false, WithSortedMapKeys())
+
+	assertMarshalMatches(t, map[int]string{
+		10: "ten",
+		2:  "two",
+		-4: "minus four",
+		35: "thirty five",
+	}, "testdata/reversed_map_of_int_keys.csv", false, WithMapKeyComparator(func(a, b any) int {
+		return b.(int) - a.(int)
+	}))
+}
+
+type Contact struct {
+	FirstName  string
+	MiddleName *string `csv:"middle_name,nil=N/A"`
+	LastName   string
+	Age        int
+	Verified   bool
+	Phone      *string
+	Address    *AddressWithOptionalState `csv:"address,nil=-"`
+}
+
+type AddressWithOptionalState struct {
+	City  string
+	State *string
+}
+
+func TestMarshaller_NilAndZeroValues(t *testing.T) {
+	val := []Contact{
+		{
+			FirstName:  "Hanna",
+			MiddleName: ptr.To("Anna"),
+			LastName:   "Banana",
+			Age:        34,
+			Verified:   true,
+			Phone:      ptr.To("555-1234"),
+			Address:    &AddressWithOptionalState{City: "New York", State: ptr.To("NY")},
+		},
+		{
+			FirstName: "June",
+			LastName:  "Prune",
+			Address:   &AddressWithOptionalState{City: "Paris"},
+		},
+		{
+			FirstName: "Barry",
+			LastName:  "Cherry",
+		},
+	}
+
+	assertMarshalMatches(t, val, "testdata/nil_values.csv", false)
+	assertMarshalMatches(t, val, "testdata/empty_zero_values.csv", false, WithEmptyZeroValues())
+}
+
+func TestValueMarshallers(t *testing.T) {
+	RegisterTypeMarshaller(reflect.TypeOf(Money{}), func(v any) (string, error) {
+		m := v.(Money)
+		return fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency), nil
+	})
+
+	RegisterNamedMarshaller("upper", func(v any) (string, error) {
+		return strings.ToUpper(v.(string)), nil
+	})
+
+	assertMarshalMatches(t, []Invoice{
+		{
+			Number:   "INV-001",
+			Customer: "Hanna Banana",
+			Total:    Money{Cents: 12050, Currency: "USD"},
+			Tax:      &Money{Cents: 1069, Currency: "USD"},
+		},
+		{
+			Number:   "INV-002",
+			Customer: "June Prune",
+			Total:    Money{Cents: 900, Currency: "EUR"},
+		},
+	}, "testdata/value_marshallers.csv", false)
+}
+
+func TestValueMarshallers_Errors(t *testing.T) {
+	_, err := NewMarshaller(reflect.TypeOf(struct {
+		Name string `csv:"name,marshaller=not-registered"`
+	}{}))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "unknown marshaller 'not-registered' for column 'name'")
+	}
+
+	RegisterNamedMarshaller("always-fails", func(v any) (string, error) {
+		return "", fmt.Errorf("nope")
+	})
+
+	val := []struct {
+		Name string `csv:"name,marshaller=always-fails"`
+	}{{Name: "foo"}}
+
+	m, err := NewMarshaller(reflect.TypeOf(val))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = m.Encode(csv.NewWriter(io.Discard), val, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "unable to convert field Name: nope", err.Error())
+	}
+}
+
+type Position struct {
+	Ticker   string
+	Quantity float32
+	Price    float64 `csv:"price,precision=2"`
+	Weight   float64 `csv:"weight,format=e,precision=3"`
+}
+
+func TestFloatFormat(t *testing.T) {
+	val := []Position{
+		{Ticker: "ACME", Quantity: 100.23, Price: 34.5, Weight: 0.0123456},
+		{Ticker: "INIT", Quantity: 12, Price: 1034.456, Weight: 0.5},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		opts     []Option
+		expected string
+	}{
+		{
+			"default",
+			nil,
+			"Ticker,Quantity,price,weight\n" +
+				"ACME,100.2300033569,34.50,1.235e-02\n" +
+				"INIT,12.0000000000,1034.46,5.000e-01\n",
+		},
+		{
+			"shortest",
+			[]Option{WithFloatFormat('f', -1)},
+			"Ticker,Quantity,price,weight\n" +
+				"ACME,100.23,34.50,1.235e-02\n" +
+				"INIT,12,1034.46,5.000e-01\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMarshaller(reflect.TypeOf(val), tt.opts...)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			var buf bytes.Buffer
+			w := csv.NewWriter(&buf)
+			_ = w.Write(m.Headers())
+			if !assert.NoError(t, m.Encode(w, val, "")) {
+				return
+			}
+
+			w.Flush()
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+type Shipment struct {
+	ID        int
+	Shipped   time.Time  `csv:"shipped,format='Jan 2, 2006'"`
+	Delivered *time.Time `csv:"delivered,format=Jan 2\\, 2006,nil=pending\\, not delivered"`
+}
+
+func TestQuotedTagOptions(t *testing.T) {
+	shipped := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.UTC)
+	delivered := shipped.AddDate(0, 0, 3)
+	val := []Shipment{
+		{ID: 1, Shipped: shipped, Delivered: &delivered},
+		{ID: 2, Shipped: shipped},
+	}
+
+	m, err := NewMarshaller(reflect.TypeOf(val))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write(m.Headers())
+	require.NoError(t, m.Encode(w, val, ""))
+	w.Flush()
+
+	assert.Equal(t, "ID,shipped,delivered\n"+
+		"1,\"Mar 5, 2024\",\"Mar 8, 2024\"\n"+
+		"2,\"Mar 5, 2024\",\"pending, not delivered\"\n", buf.String())
+}
+
+type Resource struct {
+	Name   string            `csv:"name"`
+	Labels map[string]string `csv:"labels,keys=env|team"`
+	Limits map[string]*int   `csv:"limits,keys=cpu|memory"`
+	Value  any               `csv:"value"`
+}
+
+func TestMapAndInterfaceFields(t *testing.T) {
+	assertMarshalMatches(t, []Resource{
+		{
+			Name:   "api",
+			Labels: map[string]string{"env": "prod", "team": "core", "owner": "ignored"},
+			Limits: map[string]*int{"cpu": ptr.To(2), "memory": ptr.To(512)},
+			Value:  45.5,
+		},
+		{
+			Name:   "worker",
+			Labels: map[string]string{"team": "batch"},
+			Limits: map[string]*int{"cpu": nil},
+			Value:  "busy",
+		},
+		{
+			Name:  "cron",
+			Value: Priority(2),
+		},
+		{
+			Name:  "db",
+			Value: ptr.To(true),
+		},
+	}, "testdata/struct_with_map_and_interface_fields.csv", false)
+}
+
+func TestInterfaceFieldWithStructValue(t *testing.T) {
+	val := Resource{Name: "bad", Value: Address{City: "Boston"}}
+
+	m, err := NewMarshaller(reflect.TypeOf(val))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = m.Encode(csv.NewWriter(io.Discard), val, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "unable to convert field Value: cannot convert dynamic type 'csv.Address' to a single column", err.Error())
+	}
+}
+
+type LineItem struct {
+	SKU      string  `csv:"sku"`
+	Quantity int     `csv:"qty"`
+	Price    float64 `csv:"price,precision=2"`
+}
+
+type Order struct {
+	ID       int        `csv:"id"`
+	Customer string     `csv:"customer"`
+	Items    []LineItem `csv:"item,expand"`
+	Notes    []string   `csv:"notes"`
+}
+
+func TestExpandedFields(t *testing.T) {
+	orders := []Order{
+		{
+			ID:       1,
+			Customer: "Alice",
+			Items: []LineItem{
+				{SKU: "A-100", Quantity: 2, Price: 9.99},
+				{SKU: "B-200", Quantity: 1, Price: 24.5},
+			},
+			Notes: []string{"gift", "rush"},
+		},
+		{
+			ID:       2,
+			Customer: "Bob",
+		},
+		{
+			ID:       3,
+			Customer: "Carol",
+			Items: []LineItem{
+				{SKU: "C-300", Quantity: 5, Price: 1},
+			},
+		},
+	}
+
+	assertMarshalMatches(t, orders, "testdata/expanded_fields.csv", false)
+	assertMarshalMatches(t, map[string]Order{"alice": orders[0]},
+		"testdata/map_of_expanded_fields.csv", false)
+}
+
+func TestParallelism(t *testing.T) {
+	orders := make([]*Order, 2000)
+	for i := range orders {
+		orders[i] = &Order{
+			ID:       i,
+			Customer: fmt.Sprintf("customer-%d", i),
+			Items:    make([]LineItem, i%3),
+		}
+
+		for j := range orders[i].Items {
+			orders[i].Items[j] = LineItem{SKU: fmt.Sprintf("sku-%d-%d", i, j), Quantity: j}
+		}
+	}
+
+	orders[500] = nil
+
+	marshal := func(opts ...Option) string {
+		m, err := NewMarshaller(reflect.TypeOf(orders), opts...)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		require.NoError(t, m.Encode(w, orders, ""))
+		w.Flush()
+		return buf.String()
+	}
+
+	expected := marshal()
+	for _, n := range []int{2, 4, 16} {
+		assert.Equal(t, expected, marshal(WithParallelism(n)), "parallelism %d", n)
+	}
+}
+
+func TestParallelism_Error(t *testing.T) {
+	RegisterNamedMarshaller("failing_sku", func(v any) (string, error) {
+		if v == "sku-1500" {
+			return "", fmt.Errorf("bad sku %s", v)
+		}
+
+		return v.(string), nil
+	})
+
+	type Product struct {
+		SKU string `csv:"sku,marshaller=failing_sku"`
+	}
+
+	products := make([]Product, 2000)
+	for i := range products {
+		products[i] = Product{SKU: fmt.Sprintf("sku-%d", i)}
+	}
+
+	m, err := NewMarshaller(reflect.TypeOf(products), WithParallelism(4))
+	require.NoError(t, err)
+
+	var rows int
+	err = m.Encode(rowWriterFn(func(_ []string) error {
+		rows++
+		return nil
+	}), products, "")
+	require.Error(t, err)
+	assert.Equal(t, "unable to convert field SKU: bad sku sku-1500", err.Error())
+	assert.Equal(t, 5*parallelBatchSize, rows)
+}
+
+type rowWriterFn func(record []string) error
+
+func (fn rowWriterFn) Write(record []string) error { return fn(record) }
+
+func TestListFieldTooLong(t *testing.T) {
+	val := Article{
+		Title: "Too many tags",
+		Tags:  []string{"a", "b", "c", "d"},
+	}
+
+	m, err := NewMarshaller(reflect.TypeOf(val))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	err = m.Encode(csv.NewWriter(io.Discard), val, "")
+	if assert.Error(t, err) {
+		assert.Equal(t, "unable to convert field Tags: column 'tags' has 4 elements, more than the max of 3", err.Error())
+	}
+}
+
 func TestInvalidMarshallers(t *testing.T) {
 	for _, tt := range []struct {
 		val         any
@@ -302,6 +803,91 @@ func TestInvalidMarshallers(t *testing.T) {
 			},
 			"cannot convert type '[]string' to csv",
 		},
+		{
+			struct {
+				Name string `csv:"name,order=first"`
+			}{},
+			"invalid order 'first' for field Name",
+		},
+		{
+			struct {
+				Addresses []Address
+			}{},
+			"cannot flatten '[]csv.Address' for column 'Addresses': elements must convert to a single column",
+		},
+		{
+			struct {
+				Tags []string `csv:"tags,max=none"`
+			}{},
+			"invalid max 'none' for column 'tags'",
+		},
+		{
+			struct {
+				Price float64 `csv:"price,format=z"`
+			}{},
+			"invalid float format 'z' for column 'price'",
+		},
+		{
+			struct {
+				Price float64 `csv:"price,precision=two"`
+			}{},
+			"invalid precision 'two' for column 'price'",
+		},
+		{
+			struct {
+				Price float64 `csv:"price,precison=2"`
+			}{},
+			"unknown csv tag option 'precison' for field Price",
+		},
+		{
+			struct {
+				Quantity int `csv:"qty,format=e"`
+			}{},
+			"cannot use format= for column 'qty': 'int' is not a time or floating point value",
+		},
+		{
+			struct {
+				Starts time.Time `csv:"starts,precision=2"`
+			}{},
+			"cannot use precision= for column 'starts': 'time.Time' is not a floating point value",
+		},
+		{
+			struct {
+				Starts time.Time `csv:"starts,format='Jan 2"`
+			}{},
+			"invalid csv tag for field Starts: unterminated quote",
+		},
+		{
+			struct {
+				Labels map[string]string `csv:"labels"`
+			}{},
+			"cannot convert 'map[string]string' for column 'labels': map fields require a keys= tag option",
+		},
+		{
+			struct {
+				Counts map[int]string `csv:"counts,keys=1|2"`
+			}{},
+			"cannot convert 'map[int]string' for column 'counts': map keys must be strings",
+		},
+		{
+			struct {
+				Addresses map[string]Address `csv:"addresses,keys=home|work"`
+			}{},
+			"cannot convert 'map[string]csv.Address' for column 'addresses': values must convert to a single column",
+		},
+		{
+			struct {
+				Item LineItem `csv:"item,expand"`
+			}{},
+			"cannot expand 'csv.LineItem' for column 'item': only slices and arrays can be expanded",
+		},
+		{
+			struct {
+				Items []LineItem `csv:"item,expand"`
+				Tags  []string   `csv:"tag,expand"`
+			}{},
+			"cannot expand more than one field of 'struct",
+		},
 	} {
 		typ := reflect.TypeOf(tt.val)
 		t.Run(typ.Name(), func(t *testing.T) {
@@ -360,23 +946,40 @@ func TestPrimitives(t *testing.T) {
 			uint64(math.MaxUint64 - 1),
 			[]string{"18446744073709551614"},
 		},
+		{
+			true,
+			[]string{"true"},
+		},
+		{
+			Priority(1),
+			[]string{"high"},
+		},
+		{
+			Coordinates{Lat: 40.7282, Lng: -74.0047},
+			[]string{"40.7282:-74.0047"},
+		},
+		{
+			time.Date(2023, time.October, 14, 13, 30, 0, 0, time.UTC),
+			[]string{"2023-10-14T13:30:00Z"},
+		},
 	} {
 		t.Run(reflect.TypeOf(tt.val).Name(), func(t *testing.T) {
 			val := reflect.ValueOf(tt.val)
-			rm, err := newRowMapper(val.Type())
+			rm, err := newRowMapper(val.Type(), fieldTag{}, newOptions(nil))
 			if !assert.NoError(t, err) {
 				return
 			}
 
-			actual, process := rm.Values(val, "")
+			actual, process, err := rm.Values(val, "")
+			assert.NoError(t, err)
 			assert.True(t, process)
 			assert.Equal(t, actual, tt.expected)
 		})
 	}
 }
 
-func assertMarshalMatches(t *testing.T, val any, filename string, anyOrder bool) bool {
-	m, err := NewMarshaller(reflect.TypeOf(val))
+func assertMarshalMatches(t *testing.T, val any, filename string, anyOrder bool, opts ...Option) bool {
+	m, err := NewMarshaller(reflect.TypeOf(val), opts...)
 	if !assert.NoError(t, err) {
 		return false
 	}
@@ -0,0 +1,124 @@
+package csv
+
+// An Option configures how a Marshaller or Encoder converts values to CSV.
+type Option func(opts *options)
+
+type options struct {
+	floatFormat     byte
+	floatPrec       int
+	listSeparator   string
+	writerOptions   WriterOptions
+	mapKeyCmp       func(a, b any) int
+	emptyZeroValues bool
+	nilValue        string
+	headerTransform func(string) string
+	columns         []string
+	parallelism     int
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		floatFormat:   'f',
+		floatPrec:     10,
+		listSeparator: ";",
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithFloatFormat sets the default format and precision used for floating
+// point values, with the same meaning as the fmt and prec arguments to
+// strconv.FormatFloat. A precision of -1 uses the smallest number of digits
+// necessary to represent the value exactly. Individual fields can override
+// the default with the format= and precision= tag options, e.g.
+// `csv:"amount,precision=2"`. The default is 'f' with a precision of 10.
+func WithFloatFormat(fmt byte, prec int) Option {
+	return func(opts *options) {
+		opts.floatFormat = fmt
+		opts.floatPrec = prec
+	}
+}
+
+// WithListSeparator sets the default separator used when joining the elements
+// of a slice or array field into a single column. Individual fields can override
+// the default with the sep= tag option, e.g. `csv:"tags,sep=|"`. The default is ";".
+func WithListSeparator(sep string) Option {
+	return func(opts *options) {
+		opts.listSeparator = sep
+	}
+}
+
+// WithWriterOptions sets the options used to format the CSV written by
+// Marshal, Write, and an Encoder. Marshallers write to a RowWriter provided
+// by the caller, so they ignore this option.
+func WithWriterOptions(writerOptions WriterOptions) Option {
+	return func(opts *options) {
+		opts.writerOptions = writerOptions
+	}
+}
+
+// WithSortedMapKeys writes the rows of a map in ascending key order, rather
+// than in random map iteration order, so that output is stable across runs.
+func WithSortedMapKeys() Option {
+	return WithMapKeyComparator(compareKeys)
+}
+
+// WithMapKeyComparator writes the rows of a map ordered by a comparator over
+// the map keys. The comparator returns a negative number if a sorts before b,
+// a positive number if a sorts after b, and zero if they are equal.
+func WithMapKeyComparator(cmp func(a, b any) int) Option {
+	return func(opts *options) {
+		opts.mapKeyCmp = cmp
+	}
+}
+
+// WithEmptyZeroValues writes zero values of struct fields (0, "", false, the
+// zero time.Time, etc.) as empty cells, rather than converting them as usual.
+func WithEmptyZeroValues() Option {
+	return func(opts *options) {
+		opts.emptyZeroValues = true
+	}
+}
+
+// WithNilValue sets the value written for nil fields by Marshal, Write, and
+// an Encoder. Marshallers take the nil value as an argument to Encode, so
+// they ignore this option.
+func WithNilValue(nilValue string) Option {
+	return func(opts *options) {
+		opts.nilValue = nilValue
+	}
+}
+
+// WithHeaderTransform transforms every header with the given function, after
+// the headers of nested struct fields have been prefixed with the name of the
+// containing field. SnakeCase, ScreamingSnakeCase, and strings.ToLower are
+// common transforms.
+func WithHeaderTransform(transform func(string) string) Option {
+	return func(opts *options) {
+		opts.headerTransform = transform
+	}
+}
+
+// WithColumns selects a subset of columns to write, in the given order. Columns
+// are named by their headers before any header transform is applied, with nested
+// struct fields named by their full path (e.g. "MailingAddress.City").
+func WithColumns(columns ...string) Option {
+	return func(opts *options) {
+		opts.columns = columns
+	}
+}
+
+// WithParallelism converts the elements of slices and arrays to rows across n
+// goroutines, while still writing the rows in order. This speeds up marshalling
+// large slices, where the cost is dominated by reflection. Any ValueMarshallers,
+// String, or MarshalText methods used by the elements must be safe to call
+// concurrently.
+func WithParallelism(n int) Option {
+	return func(opts *options) {
+		opts.parallelism = n
+	}
+}
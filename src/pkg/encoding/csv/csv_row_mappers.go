@@ -1,76 +1,143 @@
 package csv
 
 import (
+	"encoding"
 	"fmt"
 	"reflect"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
-// A RowMapper maps a single row into the CSV representation.
+// A RowMapper maps a single row into the CSV representation. Values returns
+// the values of the row, whether the row should be written at all, and any
+// error converting the value.
 type RowMapper interface {
 	Headers() []string
-	Values(val reflect.Value, nilValue string) ([]string, bool)
+	Values(val reflect.Value, nilValue string) ([]string, bool, error)
 }
 
-// A structMapper is a RowMapper that converts a struct into a CSV row.
+// A multiRowMapper is a RowMapper that can map a single value into
+// multiple rows, such as a struct with an expanded slice field.
+type multiRowMapper interface {
+	Rows(val reflect.Value, nilValue string) ([][]string, bool, error)
+}
+
+// mapRows maps a value into one or more rows.
+func mapRows(m RowMapper, val reflect.Value, nilValue string) ([][]string, bool, error) {
+	if mm, ok := m.(multiRowMapper); ok {
+		return mm.Rows(val, nilValue)
+	}
+
+	values, processRow, err := m.Values(val, nilValue)
+	if err != nil || !processRow {
+		return nil, processRow, err
+	}
+
+	return [][]string{values}, true, nil
+}
+
+// A structMapper is a RowMapper that converts a struct into a CSV row, or
+// into multiple rows if one of its fields is expanded.
 type structMapper struct {
-	headers      []string
-	fields       []reflect.StructField
-	fieldMappers []RowMapper
+	typ       reflect.Type
+	headers   []string
+	fields    []structField
+	emptyZero bool
+	expands   bool
+}
+
+// A structField is a single field of a struct, along with the RowMapper
+// used to convert the field and the column header(s) it produces.
+type structField struct {
+	field   reflect.StructField
+	tag     fieldTag
+	mapper  RowMapper
+	headers []string
 }
 
-func newStructMapper(typ reflect.Type) (RowMapper, error) {
-	var (
-		fields       = make([]reflect.StructField, 0, typ.NumField())
-		fieldMappers = make([]RowMapper, 0, typ.NumField())
-		headers      = make([]string, 0, typ.NumField())
-	)
+func newStructMapper(typ reflect.Type, opts *options) (RowMapper, error) {
+	fields := make([]structField, 0, typ.NumField())
 	for i := 0; i < typ.NumField(); i++ {
 		field := typ.Field(i)
 
 		// Look for a csv: tag on the field to determine the header prefix or whether
 		// to skip. If there is no csv: tag, process the field and use the name of the
 		// field as the header prefix.
-		csvTag := field.Tag.Get("csv")
-		if csvTag == "-" {
-			continue
+		tag, err := parseFieldTag(field)
+		if err != nil {
+			return nil, err
 		}
 
-		headerName := field.Name
-		if csvTag != "" {
-			headerName = csvTag
+		if tag.skip {
+			continue
 		}
 
-		fields = append(fields, field)
-
 		// Get the converter for the field
-		fieldMapper, err := newRowMapper(field.Type)
+		fieldMapper, err := newFieldMapper(field.Type, tag, opts)
 		if err != nil {
 			return nil, err
 		}
-		fieldMappers = append(fieldMappers, fieldMapper)
 
 		// Add the header(s) for the field. If the field is a compound type, prefix
 		// all field internal headers with the field name. If the field is a primitive
 		// type, the only header is the name of the field itself
+		var headers []string
 		if fieldHeaders := fieldMapper.Headers(); len(fieldHeaders) > 0 {
 			prefix := ""
 			if !field.Anonymous {
-				prefix = headerName + "."
+				prefix = tag.name + "."
 			}
 
+			headers = make([]string, 0, len(fieldHeaders))
 			for _, fieldHeader := range fieldHeaders {
 				headers = append(headers, prefix+fieldHeader)
 			}
 		} else {
-			headers = append(headers, headerName)
+			headers = []string{tag.name}
 		}
+
+		fields = append(fields, structField{
+			field:   field,
+			tag:     tag,
+			mapper:  fieldMapper,
+			headers: headers,
+		})
+	}
+
+	// Fields with an explicit order come first, sorted by that order, followed
+	// by the remaining fields in declaration order.
+	sort.SliceStable(fields, func(i, j int) bool {
+		a, b := fields[i].tag, fields[j].tag
+		if !a.hasOrder {
+			return false
+		}
+
+		return !b.hasOrder || a.order < b.order
+	})
+
+	headers := make([]string, 0, len(fields))
+	expanded := 0
+	for _, f := range fields {
+		headers = append(headers, f.headers...)
+		if expands(f.mapper) {
+			expanded++
+		}
+	}
+
+	if expanded > 1 {
+		return nil, fmt.Errorf("cannot expand more than one field of '%s'", typ.String())
 	}
 
 	return &structMapper{
-		headers:      headers,
-		fields:       fields,
-		fieldMappers: fieldMappers,
+		typ:       typ,
+		headers:   headers,
+		fields:    fields,
+		emptyZero: opts.emptyZeroValues,
+		expands:   expanded != 0,
 	}, nil
 }
 
@@ -78,70 +145,426 @@ func (c *structMapper) Headers() []string {
 	return c.headers
 }
 
-func (c *structMapper) Values(val reflect.Value, nilValue string) ([]string, bool) {
+func (c *structMapper) Values(val reflect.Value, nilValue string) ([]string, bool, error) {
+	if c.expands {
+		return nil, false, fmt.Errorf("'%s' expands to multiple rows", c.typ.String())
+	}
+
+	rows, processRow, err := c.Rows(val, nilValue)
+	if err != nil || !processRow {
+		return nil, processRow, err
+	}
+
+	return rows[0], true, nil
+}
+
+func (c *structMapper) Rows(val reflect.Value, nilValue string) ([][]string, bool, error) {
 	val = deref(val)
 	if val == zeroValue {
-		return nil, false
+		return nil, false, nil
 	}
 
-	values := make([]string, 0, len(c.fields))
-	for i, field := range c.fields {
-		fieldVal := val.FieldByIndex(field.Index)
-		if isNillable(fieldVal.Kind()) && fieldVal.IsNil() {
-			values = append(values, nilValue)
-			continue
+	rows := [][]string{make([]string, 0, len(c.headers))}
+	for _, f := range c.fields {
+		fieldRows, err := c.fieldRows(f, val, nilValue)
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to convert field %s: %w", f.field.Name, err)
 		}
 
-		if fieldValues, processRow := c.fieldMappers[i].Values(fieldVal, nilValue); processRow {
-			values = append(values, fieldValues...)
+		rows = appendRows(rows, fieldRows)
+	}
+
+	return rows, len(rows[0]) != 0, nil
+}
+
+func (c *structMapper) fieldRows(f structField, val reflect.Value, nilValue string) ([][]string, error) {
+	// Fields can override the nil value, which also applies to
+	// any nil values nested within the field.
+	fieldNilValue := nilValue
+	if tagNilValue, ok := f.tag.opts["nil"]; ok {
+		fieldNilValue = tagNilValue
+	}
+
+	fieldVal := val.FieldByIndex(f.field.Index)
+	if isNillable(fieldVal.Kind()) && fieldVal.IsNil() {
+		if nh, ok := f.mapper.(nilHandler); ok {
+			return [][]string{nh.nilValues(fieldNilValue)}, nil
 		}
+
+		return [][]string{{fieldNilValue}}, nil
+	}
+
+	if c.emptyZero && len(f.mapper.Headers()) == 0 && deref(fieldVal).IsZero() {
+		return [][]string{{""}}, nil
+	}
+
+	fieldRows, processRow, err := mapRows(f.mapper, fieldVal, fieldNilValue)
+	if err != nil {
+		return nil, err
+	}
+
+	if !processRow {
+		return [][]string{nil}, nil
 	}
 
-	return values, len(values) != 0
+	return fieldRows, nil
 }
 
-// newRowMapper creates a new RowMapper for a given type.
-func newRowMapper(typ reflect.Type) (RowMapper, error) {
+func (c *structMapper) nilValues(nilValue string) []string {
+	return repeat(nilValue, len(c.headers))
+}
+
+// appendRows appends the values of a field to each row. If the field
+// has multiple rows, each row is repeated once per field row.
+func appendRows(rows [][]string, fieldRows [][]string) [][]string {
+	if len(fieldRows) == 1 {
+		for i := range rows {
+			rows[i] = append(rows[i], fieldRows[0]...)
+		}
+
+		return rows
+	}
+
+	expanded := make([][]string, 0, len(rows)*len(fieldRows))
+	for _, row := range rows {
+		for _, fieldRow := range fieldRows {
+			expanded = append(expanded, append(slices.Clip(row), fieldRow...))
+		}
+	}
+
+	return expanded
+}
+
+// expands returns true if the RowMapper maps values into multiple rows.
+func expands(m RowMapper) bool {
+	switch m := m.(type) {
+	case *expandedListRowMapper:
+		return true
+	case *structMapper:
+		return m.expands
+	default:
+		return false
+	}
+}
+
+// A nilHandler is a RowMapper that controls the values produced for a nil
+// field, rather than the field being replaced with a single nil value.
+type nilHandler interface {
+	nilValues(nilValue string) []string
+}
+
+// newFieldMapper creates a new RowMapper for a struct field. In addition to
+// the types supported by newRowMapper, fields can be slices or arrays of values
+// that each convert to a single column or are expanded into a row per element,
+// or maps with a declared set of keys.
+func newFieldMapper(typ reflect.Type, tag fieldTag, opts *options) (RowMapper, error) {
+	typ = derefType(typ)
+	if _, ok := tag.opts["expand"]; ok && !isList(typ) {
+		return nil, fmt.Errorf("cannot expand '%s' for column '%s': only slices and arrays can be expanded",
+			typ.String(), tag.name)
+	}
+
+	if hasValueConversion(typ, tag) {
+		return newRowMapper(typ, tag, opts)
+	}
+
+	if isList(typ) {
+		return newListFieldMapper(typ, tag, opts)
+	}
+
+	if typ.Kind() == reflect.Map {
+		return newMapFieldMapper(typ, tag, opts)
+	}
+
+	return newRowMapper(typ, tag, opts)
+}
+
+// newRowMapper creates a new RowMapper for a given type. The tag holds
+// the options of the struct field being converted, if any.
+func newRowMapper(typ reflect.Type, tag fieldTag, opts *options) (RowMapper, error) {
 	for typ.Kind() == reflect.Ptr {
 		typ = typ.Elem()
 	}
 
+	if err := checkFormatOptions(typ, tag); err != nil {
+		return nil, err
+	}
+
+	if name, ok := tag.opts["marshaller"]; ok {
+		fn, ok := LookupNamedMarshaller(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown marshaller '%s' for column '%s'", name, tag.name)
+		}
+
+		return &valueMarshallerRowMapper{fn: fn}, nil
+	}
+
+	if fn, ok := LookupTypeMarshaller(typ); ok {
+		return &valueMarshallerRowMapper{fn: fn}, nil
+	}
+
+	if typ == timeType {
+		layout := tag.opts["format"]
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		return &timeRowMapper{layout: layout}, nil
+	}
+
+	if implements(typ, textMarshalerType) {
+		return &textMarshalerRowMapper{}, nil
+	}
+
+	if implements(typ, stringerType) {
+		return &stringerRowMapper{}, nil
+	}
+
 	if isPrimitive(typ.Kind()) {
-		return &primitiveRowMapper{}, nil
+		return newPrimitiveRowMapper(typ, tag, opts)
+	}
+
+	if typ.Kind() == reflect.Interface {
+		return &interfaceRowMapper{tag: tag, opts: opts}, nil
 	}
 
 	if typ.Kind() == reflect.Struct {
-		return newStructMapper(typ)
+		return newStructMapper(typ, opts)
 	}
 
 	return nil, fmt.Errorf("cannot convert type '%s' to csv", typ.String())
 }
 
+// checkFormatOptions checks that the format= and precision= tag options are
+// only used on the kinds of values they apply to. The format= option is a
+// layout for time.Time values and a strconv.FormatFloat verb for floating
+// point values, while precision= only applies to floating point values.
+// Interfaces are checked against their dynamic type once it is known.
+func checkFormatOptions(typ reflect.Type, tag fieldTag) error {
+	if typ.Kind() == reflect.Interface {
+		return nil
+	}
+
+	isFloat := typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64
+	if _, ok := tag.opts["format"]; ok && !isFloat && typ != timeType {
+		return fmt.Errorf("cannot use format= for column '%s': '%s' is not a time or floating point value",
+			tag.name, typ.String())
+	}
+
+	if _, ok := tag.opts["precision"]; ok && !isFloat {
+		return fmt.Errorf("cannot use precision= for column '%s': '%s' is not a floating point value",
+			tag.name, typ.String())
+	}
+
+	return nil
+}
+
 // primitiveRowMapper maps a primitive value to a row.
 type primitiveRowMapper struct {
+	floatFormat byte
+	floatPrec   int
+}
+
+func newPrimitiveRowMapper(typ reflect.Type, tag fieldTag, opts *options) (RowMapper, error) {
+	m := &primitiveRowMapper{
+		floatFormat: opts.floatFormat,
+		floatPrec:   opts.floatPrec,
+	}
+
+	if typ.Kind() != reflect.Float32 && typ.Kind() != reflect.Float64 {
+		return m, nil
+	}
+
+	if format, ok := tag.opts["format"]; ok {
+		if len(format) != 1 || !strings.Contains("beEfgGxX", format) {
+			return nil, fmt.Errorf("invalid float format '%s' for column '%s'", format, tag.name)
+		}
+
+		m.floatFormat = format[0]
+	}
+
+	if precision, ok := tag.opts["precision"]; ok {
+		n, err := strconv.Atoi(precision)
+		if err != nil {
+			return nil, fmt.Errorf("invalid precision '%s' for column '%s': %w", precision, tag.name, err)
+		}
+
+		m.floatPrec = n
+	}
+
+	return m, nil
 }
 
 func (c *primitiveRowMapper) Headers() []string { return nil }
-func (c *primitiveRowMapper) Values(val reflect.Value, _ string) ([]string, bool) {
+func (c *primitiveRowMapper) Values(val reflect.Value, _ string) ([]string, bool, error) {
 	val = deref(val)
 
 	if val.CanFloat() {
-		return []string{strconv.FormatFloat(val.Float(), 'f', 10, 64)}, true
+		return []string{strconv.FormatFloat(val.Float(), c.floatFormat, c.floatPrec, val.Type().Bits())}, true, nil
 	}
 
 	if val.CanInt() {
-		return []string{strconv.FormatInt(val.Int(), 10)}, true
+		return []string{strconv.FormatInt(val.Int(), 10)}, true, nil
 	}
 
 	if val.CanUint() {
-		return []string{strconv.FormatUint(val.Uint(), 10)}, true
+		return []string{strconv.FormatUint(val.Uint(), 10)}, true, nil
 	}
 
 	if val.CanComplex() {
-		return []string{strconv.FormatComplex(val.Complex(), 'g', 10, 128)}, true
+		return []string{strconv.FormatComplex(val.Complex(), 'g', 10, 128)}, true, nil
+	}
+
+	if val.Kind() == reflect.Bool {
+		return []string{strconv.FormatBool(val.Bool())}, true, nil
+	}
+
+	return []string{deref(val).String()}, true, nil
+}
+
+// interfaceRowMapper maps an interface value to a single column, converting
+// the value according to its dynamic type.
+type interfaceRowMapper struct {
+	tag     fieldTag
+	opts    *options
+	mappers sync.Map // map[reflect.Type]RowMapper
+}
+
+func (c *interfaceRowMapper) Headers() []string { return nil }
+func (c *interfaceRowMapper) Values(val reflect.Value, nilValue string) ([]string, bool, error) {
+	for val.Kind() == reflect.Interface || val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return []string{nilValue}, true, nil
+		}
+
+		val = val.Elem()
+	}
+
+	mapper, err := c.mapperFor(val.Type())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return mapper.Values(val, nilValue)
+}
+
+func (c *interfaceRowMapper) mapperFor(typ reflect.Type) (RowMapper, error) {
+	if mapper, ok := c.mappers.Load(typ); ok {
+		return mapper.(RowMapper), nil
+	}
+
+	mapper, err := newRowMapper(typ, c.tag, c.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mapper.Headers()) != 0 {
+		return nil, fmt.Errorf("cannot convert dynamic type '%s' to a single column", typ.String())
+	}
+
+	c.mappers.Store(typ, mapper)
+	return mapper, nil
+}
+
+// timeRowMapper maps a time.Time to a row, formatting it with a layout.
+type timeRowMapper struct {
+	layout string
+}
+
+func (c *timeRowMapper) Headers() []string { return nil }
+func (c *timeRowMapper) Values(val reflect.Value, _ string) ([]string, bool, error) {
+	tm, err := valueAs[time.Time](deref(val))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return []string{tm.Format(c.layout)}, true, nil
+}
+
+// textMarshalerRowMapper maps a value implementing encoding.TextMarshaler to a row.
+type textMarshalerRowMapper struct {
+}
+
+func (c *textMarshalerRowMapper) Headers() []string { return nil }
+func (c *textMarshalerRowMapper) Values(val reflect.Value, _ string) ([]string, bool, error) {
+	m, err := valueAs[encoding.TextMarshaler](deref(val))
+	if err != nil {
+		return nil, false, err
+	}
+
+	text, err := m.MarshalText()
+	if err != nil {
+		return nil, false, err
+	}
+
+	return []string{string(text)}, true, nil
+}
+
+// stringerRowMapper maps a value implementing fmt.Stringer to a row.
+type stringerRowMapper struct {
+}
+
+func (c *stringerRowMapper) Headers() []string { return nil }
+func (c *stringerRowMapper) Values(val reflect.Value, _ string) ([]string, bool, error) {
+	s, err := valueAs[fmt.Stringer](deref(val))
+	if err != nil {
+		return nil, false, err
+	}
+
+	return []string{s.String()}, true, nil
+}
+
+// hasValueConversion returns true if values of the type are converted to
+// a single column as a whole, via a ValueMarshaller or marshalling interface.
+func hasValueConversion(typ reflect.Type, tag fieldTag) bool {
+	if _, ok := tag.opts["marshaller"]; ok {
+		return true
+	}
+
+	if _, ok := LookupTypeMarshaller(typ); ok {
+		return true
+	}
+
+	return typ == timeType || implements(typ, textMarshalerType) || implements(typ, stringerType)
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+)
+
+// implements returns true if either the type or a pointer to the type
+// implements the given interface.
+func implements(typ, iface reflect.Type) bool {
+	return typ.Implements(iface) || reflect.PointerTo(typ).Implements(iface)
+}
+
+// valueAs returns the value as an implementation of T, taking the address
+// of the value (or a copy of the value) if T is implemented with pointer receivers.
+func valueAs[T any](val reflect.Value) (T, error) {
+	var noop T
+	if !val.CanInterface() {
+		return noop, fmt.Errorf("cannot access unexported value of type '%s'", val.Type())
 	}
 
-	return []string{deref(val).String()}, true
+	if v, ok := val.Interface().(T); ok {
+		return v, nil
+	}
+
+	var ptr reflect.Value
+	if val.CanAddr() {
+		ptr = val.Addr()
+	} else {
+		ptr = reflect.New(val.Type())
+		ptr.Elem().Set(val)
+	}
+
+	if v, ok := ptr.Interface().(T); ok {
+		return v, nil
+	}
+
+	return noop, fmt.Errorf("type '%s' does not implement '%s'", val.Type(), reflect.TypeOf(&noop).Elem())
 }
 
 func isNillable(kind reflect.Kind) bool {
@@ -159,6 +582,18 @@ var nillable = map[reflect.Kind]struct{}{
 	reflect.Slice:         {},
 }
 
+func repeat(s string, n int) []string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = s
+	}
+	return values
+}
+
+func isList(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array
+}
+
 func isPrimitive(kind reflect.Kind) bool {
 	_, ok := primitives[kind]
 	return ok
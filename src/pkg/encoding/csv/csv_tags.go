@@ -0,0 +1,112 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// fieldTag is the parsed form of a `csv:"..."` struct tag. The first element
+// of the tag is the column name (or "-" to skip the field), followed by
+// optional comma separated options, e.g. `csv:"zip_code,order=3"`. Option
+// values containing commas can be wrapped in single quotes, e.g.
+// `csv:"date,format='Jan 2, 2006'"`, and a backslash escapes the character
+// that follows it.
+type fieldTag struct {
+	name     string
+	skip     bool
+	order    int
+	hasOrder bool
+	opts     map[string]string
+}
+
+// tagOptions are the options supported in a csv tag.
+var tagOptions = map[string]struct{}{
+	"order":      {},
+	"nil":        {},
+	"expand":     {},
+	"max":        {},
+	"sep":        {},
+	"keys":       {},
+	"marshaller": {},
+	"format":     {},
+	"precision":  {},
+}
+
+func parseFieldTag(field reflect.StructField) (fieldTag, error) {
+	csvTag := field.Tag.Get("csv")
+	if csvTag == "-" {
+		return fieldTag{skip: true}, nil
+	}
+
+	parts, err := splitTag(csvTag)
+	if err != nil {
+		return fieldTag{}, fmt.Errorf("invalid csv tag for field %s: %w", field.Name, err)
+	}
+
+	tag := fieldTag{
+		name: parts[0],
+		opts: make(map[string]string, len(parts)-1),
+	}
+
+	if tag.name == "" {
+		tag.name = field.Name
+	}
+
+	for _, opt := range parts[1:] {
+		key, val, _ := strings.Cut(opt, "=")
+		key = strings.TrimSpace(key)
+		if _, ok := tagOptions[key]; !ok {
+			return fieldTag{}, fmt.Errorf("unknown csv tag option '%s' for field %s", key, field.Name)
+		}
+
+		tag.opts[key] = val
+	}
+
+	if order, ok := tag.opts["order"]; ok {
+		n, err := strconv.Atoi(order)
+		if err != nil {
+			return fieldTag{}, fmt.Errorf("invalid order '%s' for field %s: %w", order, field.Name, err)
+		}
+
+		tag.order, tag.hasOrder = n, true
+	}
+
+	return tag, nil
+}
+
+// splitTag splits a tag on commas, other than commas within single quotes
+// or escaped with a backslash. Quotes and escapes are removed from the result.
+func splitTag(csvTag string) ([]string, error) {
+	var (
+		parts  []string
+		sb     strings.Builder
+		quoted bool
+	)
+
+	for i := 0; i < len(csvTag); i++ {
+		switch c := csvTag[i]; {
+		case c == '\\':
+			if i++; i == len(csvTag) {
+				return nil, errors.New("trailing backslash")
+			}
+
+			sb.WriteByte(csvTag[i])
+		case c == '\'':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			parts = append(parts, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(c)
+		}
+	}
+
+	if quoted {
+		return nil, errors.New("unterminated quote")
+	}
+
+	return append(parts, sb.String()), nil
+}
@@ -0,0 +1,60 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal(t *testing.T) {
+	b, err := Marshal(addresses)
+	require.NoError(t, err)
+	assertCSVFilesMatch(t, "testdata/slice_of_ptr_to_structs.csv", string(b), false)
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, []Person{{FirstName: "June", LastName: "Prune"}},
+		WithNilValue("N/A"),
+		WithWriterOptions(WriterOptions{Comma: '\t'}))
+	require.NoError(t, err)
+	assert.Equal(t, "FirstName\tLastName\tMailingAddress.Street1\tMailingAddress.City\t"+
+		"MailingAddress.State\tMailingAddress.Zipcode\n"+
+		"June\tPrune\tN/A\tN/A\tN/A\tN/A\n", buf.String())
+}
+
+func TestMarshal_Pointers(t *testing.T) {
+	rows := []Person{{FirstName: "June", LastName: "Prune"}}
+	b, err := Marshal(&rows)
+	require.NoError(t, err)
+	assert.Equal(t, "FirstName,LastName,MailingAddress.Street1,MailingAddress.City,"+
+		"MailingAddress.State,MailingAddress.Zipcode\n"+
+		"June,Prune,,,,\n", string(b))
+
+	m := map[string]int{"a": 1, "b": 2}
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, &m, WithSortedMapKeys()))
+	assert.Equal(t, "key,val\na,1\nb,2\n", buf.String())
+
+	// Nil pointers have no rows
+	var nilRows *[]Person
+	b, err = Marshal(nilRows)
+	require.NoError(t, err)
+	assert.Equal(t, "FirstName,LastName,MailingAddress.Street1,MailingAddress.City,"+
+		"MailingAddress.State,MailingAddress.Zipcode\n", string(b))
+
+	var nilMap *map[string]int
+	b, err = Marshal(nilMap)
+	require.NoError(t, err)
+	assert.Equal(t, "key,val\n", string(b))
+}
+
+func TestMarshal_Errors(t *testing.T) {
+	_, err := Marshal(nil)
+	assert.EqualError(t, err, "unable to marshal nil value")
+
+	_, err = Marshal(100)
+	assert.EqualError(t, err, "unable to create Marshaller for 'int'")
+}
@@ -0,0 +1,70 @@
+package csv
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// A ValueMarshaller converts a single value into its CSV representation.
+type ValueMarshaller func(v any) (string, error)
+
+// RegisterTypeMarshaller registers a ValueMarshaller for a given type. The
+// ValueMarshaller is used for every field or value of that type, taking
+// precedence over the default conversions. Pointer types are dereferenced
+// before lookup, so the type should be the non-pointer type.
+func RegisterTypeMarshaller(typ reflect.Type, fn ValueMarshaller) {
+	typeMarshallers.Store(typ, fn)
+}
+
+// LookupTypeMarshaller returns the ValueMarshaller registered for a given type.
+func LookupTypeMarshaller(typ reflect.Type) (ValueMarshaller, bool) {
+	fn, ok := typeMarshallers.Load(typ)
+	if !ok {
+		return nil, ok
+	}
+
+	return fn.(ValueMarshaller), ok
+}
+
+// RegisterNamedMarshaller registers a ValueMarshaller under a name. Struct
+// fields can select a named ValueMarshaller with the marshaller= tag option,
+// e.g. `csv:"amount,marshaller=money"`.
+func RegisterNamedMarshaller(name string, fn ValueMarshaller) {
+	namedMarshallers.Store(name, fn)
+}
+
+// LookupNamedMarshaller returns the ValueMarshaller registered under a given name.
+func LookupNamedMarshaller(name string) (ValueMarshaller, bool) {
+	fn, ok := namedMarshallers.Load(name)
+	if !ok {
+		return nil, ok
+	}
+
+	return fn.(ValueMarshaller), ok
+}
+
+var (
+	typeMarshallers  = sync.Map{}
+	namedMarshallers = sync.Map{}
+)
+
+// valueMarshallerRowMapper maps a value to a row using a ValueMarshaller.
+type valueMarshallerRowMapper struct {
+	fn ValueMarshaller
+}
+
+func (c *valueMarshallerRowMapper) Headers() []string { return nil }
+func (c *valueMarshallerRowMapper) Values(val reflect.Value, _ string) ([]string, bool, error) {
+	val = deref(val)
+	if !val.CanInterface() {
+		return nil, false, fmt.Errorf("cannot access unexported value of type '%s'", val.Type())
+	}
+
+	s, err := c.fn(val.Interface())
+	if err != nil {
+		return nil, false, err
+	}
+
+	return []string{s}, true, nil
+}
@@ -0,0 +1,161 @@
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"io"
+	"unicode/utf8"
+)
+
+// A RowWriter writes rows of CSV. Both a *Writer and the standard
+// library *csv.Writer are RowWriters.
+type RowWriter interface {
+	Write(record []string) error
+}
+
+// WriterOptions control the format of the CSV produced by a Writer.
+type WriterOptions struct {
+	// Comma is the field delimiter. Defaults to ','; set to '\t' for TSV.
+	Comma rune
+
+	// UseCRLF terminates each row with \r\n rather than \n.
+	UseCRLF bool
+
+	// AlwaysQuote quotes every field, rather than only those fields
+	// that contain delimiters, quotes, or line breaks.
+	AlwaysQuote bool
+
+	// BOM writes a UTF-8 byte order mark before the first row, which
+	// Excel requires to detect UTF-8 encoded files.
+	BOM bool
+}
+
+// A Writer writes CSV rows according to a set of WriterOptions. Rows
+// are buffered; callers must call Flush once all rows have been written.
+type Writer struct {
+	opts       WriterOptions
+	w          *bufio.Writer
+	csvw       *csv.Writer
+	bomWritten bool
+	err        error
+}
+
+// NewWriter returns a new Writer that writes to w.
+func NewWriter(w io.Writer, opts WriterOptions) *Writer {
+	if opts.Comma == 0 {
+		opts.Comma = ','
+	}
+
+	bw := bufio.NewWriter(w)
+	csvw := csv.NewWriter(bw)
+	csvw.Comma = opts.Comma
+	csvw.UseCRLF = opts.UseCRLF
+
+	return &Writer{
+		opts: opts,
+		w:    bw,
+		csvw: csvw,
+	}
+}
+
+// Write writes a single row.
+func (w *Writer) Write(record []string) error {
+	if !validDelim(w.opts.Comma) {
+		return errInvalidDelim
+	}
+
+	if w.opts.BOM && !w.bomWritten {
+		w.bomWritten = true
+		if _, err := w.w.WriteRune('\uFEFF'); err != nil {
+			return err
+		}
+	}
+
+	if !w.opts.AlwaysQuote {
+		return w.csvw.Write(record)
+	}
+
+	for i, field := range record {
+		if i > 0 {
+			if _, err := w.w.WriteRune(w.opts.Comma); err != nil {
+				return err
+			}
+		}
+
+		if err := w.writeQuoted(field); err != nil {
+			return err
+		}
+	}
+
+	return w.writeLineEnd()
+}
+
+func (w *Writer) writeQuoted(field string) error {
+	if err := w.w.WriteByte('"'); err != nil {
+		return err
+	}
+
+	for _, r := range field {
+		var err error
+		switch r {
+		case '"':
+			_, err = w.w.WriteString(`""`)
+		case '\r':
+			if !w.opts.UseCRLF {
+				err = w.w.WriteByte('\r')
+			}
+		case '\n':
+			err = w.writeLineEnd()
+		default:
+			_, err = w.w.WriteRune(r)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return w.w.WriteByte('"')
+}
+
+func (w *Writer) writeLineEnd() error {
+	if w.opts.UseCRLF {
+		_, err := w.w.WriteString("\r\n")
+		return err
+	}
+
+	return w.w.WriteByte('\n')
+}
+
+// Flush writes any buffered rows to the underlying io.Writer. To check
+// if an error occurred during Flush, call Error.
+func (w *Writer) Flush() {
+	w.csvw.Flush()
+	if err := w.csvw.Error(); err != nil {
+		w.err = err
+		return
+	}
+
+	w.err = w.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *Writer) Error() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.csvw.Error()
+}
+
+var errInvalidDelim = errors.New("csv: invalid field delimiter")
+
+func validDelim(r rune) bool {
+	return r != 0 && r != '"' && r != '\r' && r != '\n' && utf8.ValidRune(r) && r != utf8.RuneError
+}
+
+var (
+	_ RowWriter = &Writer{}
+	_ RowWriter = &csv.Writer{}
+)
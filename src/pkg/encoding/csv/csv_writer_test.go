@@ -0,0 +1,95 @@
+package csv
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter(t *testing.T) {
+	rows := [][]string{
+		{"Name", "Comment"},
+		{"Hanna Banana", `said "hello"`},
+		{"June Prune", "first line\nsecond line"},
+		{"Barry Cherry", "a,b\tc"},
+	}
+
+	for _, tt := range []struct {
+		name     string
+		opts     WriterOptions
+		expected string
+	}{
+		{
+			"default",
+			WriterOptions{},
+			"Name,Comment\n" +
+				"Hanna Banana,\"said \"\"hello\"\"\"\n" +
+				"June Prune,\"first line\nsecond line\"\n" +
+				"Barry Cherry,\"a,b\tc\"\n",
+		},
+		{
+			"tsv",
+			WriterOptions{Comma: '\t'},
+			"Name\tComment\n" +
+				"Hanna Banana\t\"said \"\"hello\"\"\"\n" +
+				"June Prune\t\"first line\nsecond line\"\n" +
+				"Barry Cherry\t\"a,b\tc\"\n",
+		},
+		{
+			"always quote",
+			WriterOptions{AlwaysQuote: true},
+			"\"Name\",\"Comment\"\n" +
+				"\"Hanna Banana\",\"said \"\"hello\"\"\"\n" +
+				"\"June Prune\",\"first line\nsecond line\"\n" +
+				"\"Barry Cherry\",\"a,b\tc\"\n",
+		},
+		{
+			"excel",
+			WriterOptions{UseCRLF: true, AlwaysQuote: true, BOM: true},
+			"\uFEFF\"Name\",\"Comment\"\r\n" +
+				"\"Hanna Banana\",\"said \"\"hello\"\"\"\r\n" +
+				"\"June Prune\",\"first line\r\nsecond line\"\r\n" +
+				"\"Barry Cherry\",\"a,b\tc\"\r\n",
+		},
+		{
+			"bom and crlf without quoting",
+			WriterOptions{UseCRLF: true, BOM: true},
+			"\uFEFFName,Comment\r\n" +
+				"Hanna Banana,\"said \"\"hello\"\"\"\r\n" +
+				"June Prune,\"first line\r\nsecond line\"\r\n" +
+				"Barry Cherry,\"a,b\tc\"\r\n",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, tt.opts)
+			for _, row := range rows {
+				require.NoError(t, w.Write(row))
+			}
+
+			w.Flush()
+			require.NoError(t, w.Error())
+			assert.Equal(t, tt.expected, buf.String())
+		})
+	}
+}
+
+func TestWriter_InvalidDelimiter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WriterOptions{Comma: '"'})
+	assert.Error(t, w.Write([]string{"a", "b"}))
+}
+
+func TestEncoder_WriterOptions(t *testing.T) {
+	var buf bytes.Buffer
+	enc, err := NewEncoder(&buf, reflect.TypeOf(Address{}),
+		WithWriterOptions(WriterOptions{Comma: '\t'}))
+	require.NoError(t, err)
+
+	require.NoError(t, enc.EncodeRow(addresses[0]))
+	require.NoError(t, enc.Flush())
+	assert.Equal(t, "Street1\tCity\tState\tZipcode\n209 W Houston St\tNew York\tNY\t10014\n", buf.String())
+}
@@ -3,18 +3,46 @@
 package lifecycle
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-type state int
+// ErrStopped is the cause of the cancellation of a State's Context when the
+// state is stopped without an error.
+var ErrStopped = errors.New("lifecycle state stopped")
 
+// A Status is the current status of a State.
+type Status int
+
+// The possible statuses of a State, in the order they are reached.
 const (
-	stateInit state = iota
-	stateStarted
-	stateStopped
-	stateClosed
+	StatusInit Status = iota
+	StatusRunning
+	StatusStopped
+	StatusClosed
 )
 
+// String returns the name of the status.
+func (st Status) String() string {
+	switch st {
+	case StatusInit:
+		return "Init"
+	case StatusRunning:
+		return "Running"
+	case StatusStopped:
+		return "Stopped"
+	case StatusClosed:
+		return "Closed"
+	default:
+		return fmt.Sprintf("Status(%d)", int(st))
+	}
+}
+
 // State is a very simple goroutine-safe Init -> Running -> Stopped -> Closed state machine,
 // allowing goroutines to listen on state transitions. States start in the Init state,
 // then transition to the Running state in response to a call to the Start method. Applications
@@ -27,66 +55,298 @@ const (
 // the state to transition as desired.
 type State struct {
 	mut     sync.RWMutex
-	state   state
+	status  Status
 	started chan struct{}
 	stopped chan struct{}
 	closed  chan struct{}
+	ctx     context.Context
+	cancel  context.CancelCauseFunc
+	hooks   [StatusClosed + 1][]func()
+	err     error
+	aborted bool
+	gauge   func(Status)
+	subs    []*subscription
+
+	// Hooks and subscriber notifications waiting to run, in order, and
+	// whether a goroutine is already running them
+	pending []func()
+	running bool
+}
+
+// A StateObserver is a read-only view of a State, for code that should be
+// able to follow the state's transitions but not make them.
+type StateObserver interface {
+	Current() Status
+	String() string
+	Err() error
+	IfRunning(fn func()) bool
+
+	Running() <-chan struct{}
+	Stopped() <-chan struct{}
+	Closed() <-chan struct{}
+	WaitRunning(ctx context.Context) error
+	WaitStopped(ctx context.Context) error
+	WaitClosed(ctx context.Context) error
+
+	Context() context.Context
+	StoppingContext(parent context.Context) (context.Context, context.CancelFunc)
+
+	OnStart(fn func())
+	OnStop(fn func())
+	OnClose(fn func())
+	Subscribe(fn func(Transition)) (unsubscribe func())
+}
+
+// A Transition is an event describing a change in the status of a State.
+type Transition struct {
+	From Status
+	To   Status
+	Time time.Time
+}
+
+type subscription struct {
+	fn       func(Transition)
+	canceled atomic.Bool
 }
 
 // NewState creates a new State in the Initialized state,
 func NewState() *State {
+	ctx, cancel := context.WithCancelCause(context.Background())
 	return &State{
 		started: make(chan struct{}),
 		stopped: make(chan struct{}),
 		closed:  make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
 	}
 }
 
 // Start transitions from the "Initialized" state to the "Running" state.
 func (s *State) Start() bool {
-	s.mut.Lock()
-	defer s.mut.Unlock()
+	return s.transition(StatusInit, StatusRunning, func() {
+		close(s.started)
+	})
+}
+
+// Stop transitions from the "Running" state to the "Stopped" state.
+// Goroutines blocked on the Stopped() channel will wake up once this
+// transition is complete.
+func (s *State) Stop() bool {
+	return s.StopWithError(nil)
+}
+
+// StopWithError transitions from the "Running" state to the "Stopped" state,
+// recording the error that caused the stop. The error is returned by Err, and
+// is the cause of the cancellation of the state's Context. A nil error is a
+// clean stop.
+func (s *State) StopWithError(err error) bool {
+	// Fails if either it's never started, or it's already been stopped
+	return s.transition(StatusRunning, StatusStopped, func() {
+		s.err = err
+		close(s.stopped)
+		if err != nil {
+			s.cancel(err)
+		} else {
+			s.cancel(ErrStopped)
+		}
+	})
+}
+
+// Close transitions from the "Stopped" state to the "Closed" state.
+// Goroutines blocked on the Closed() channel will wake up once this
+// transition is complete.
+func (s *State) Close() bool {
+	return s.CloseWithError(nil)
+}
+
+// CloseWithError transitions from the "Stopped" state to the "Closed" state,
+// recording the error that occurred while closing. If the state was already
+// stopped with an error, the two errors are joined.
+func (s *State) CloseWithError(err error) bool {
+	return s.transition(StatusStopped, StatusClosed, func() {
+		s.err = errors.Join(s.err, err)
+		close(s.closed)
+	})
+}
+
+// Abort transitions from the "Initialized" state directly to the "Closed"
+// state, for components that fail before they start running. The error is
+// recorded as by StopWithError. The state never reaches "Running", so
+// OnStart hooks are discarded rather than called, while OnStop and OnClose
+// hooks run as usual.
+func (s *State) Abort(err error) bool {
+	return s.transition(StatusInit, StatusClosed, func() {
+		s.err = err
+		s.aborted = true
+		s.hooks[StatusRunning] = nil
+		close(s.stopped)
+		close(s.closed)
+		if err != nil {
+			s.cancel(err)
+		} else {
+			s.cancel(ErrStopped)
+		}
+	})
+}
+
+// Err returns the errors recorded by StopWithError, CloseWithError, and Abort, or
+// nil if the state has not terminated or terminated cleanly.
+func (s *State) Err() error {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.err
+}
 
-	if s.state != stateInit {
+// transition moves from one state to another, calling fn while holding the
+// lock, then runs the hooks registered for the new state, and any states
+// skipped over, followed by the subscribers, outside the lock.
+func (s *State) transition(from, to Status, fn func()) bool {
+	s.mut.Lock()
+	if s.status != from {
+		s.mut.Unlock()
 		return false
 	}
 
-	s.state = stateStarted
-	close(s.started)
+	s.status = to
+	t := Transition{From: from, To: to, Time: time.Now()}
+	fn()
+	if s.gauge != nil {
+		s.gauge(to)
+	}
+
+	for st := from + 1; st <= to; st++ {
+		s.pending = append(s.pending, s.hooks[st]...)
+		s.hooks[st] = nil
+	}
+
+	if len(s.subs) != 0 {
+		subs := slices.Clone(s.subs)
+		s.pending = append(s.pending, func() {
+			for _, sub := range subs {
+				if !sub.canceled.Load() {
+					sub.fn(t)
+				}
+			}
+		})
+	}
+	s.mut.Unlock()
+
+	s.runPending()
 	return true
 }
 
-// Stop transitions from the "Running" state to the "Stopped" state.
-// Goroutines blocked on the Stopped() channel will wake up once this
-// transition is complete.
-func (s *State) Stop() bool {
+// runPending runs the pending hooks and subscriber notifications outside the
+// lock, unless another goroutine is already running them, in which case that
+// goroutine runs them once it has finished with the earlier ones. This keeps
+// callbacks in order even when transitions are made concurrently.
+func (s *State) runPending() {
 	s.mut.Lock()
 	defer s.mut.Unlock()
+	if s.running {
+		return
+	}
 
-	if s.state != stateStarted {
-		// Either it's never started, or it's already been stopped
-		return false
+	s.running = true
+	for len(s.pending) > 0 {
+		fn := s.pending[0]
+		s.pending = s.pending[1:]
+
+		s.mut.Unlock()
+		fn()
+		s.mut.Lock()
 	}
 
-	s.state = stateStopped
-	close(s.stopped)
-	return true
+	s.running = false
 }
 
-// Close transitions from the "Stopped" state to the "Closed" state.
-// Goroutines blocked on the Closed() channel will wake up once this
-// transition is complete.
-func (s *State) Close() bool {
+// Subscribe registers a function to be called with every subsequent
+// transition of the state, after any hooks for the transition have run.
+// Subscribers are called in the order they subscribed, by the goroutine
+// making the transition, and receive transitions in the order they were made.
+// If a transition is made while the hooks or subscribers of an earlier one are
+// still running, the goroutine running them also runs those of the later
+// transition. Returns a function that cancels the subscription.
+func (s *State) Subscribe(fn func(Transition)) (unsubscribe func()) {
+	sub := &subscription{fn: fn}
+
 	s.mut.Lock()
 	defer s.mut.Unlock()
+	s.subs = append(s.subs, sub)
 
-	if s.state != stateStopped {
-		return false
+	return func() {
+		s.mut.Lock()
+		defer s.mut.Unlock()
+		s.subs = slices.DeleteFunc(s.subs, func(other *subscription) bool {
+			return other == sub
+		})
+		sub.canceled.Store(true)
 	}
+}
 
-	s.state = stateClosed
-	close(s.closed)
-	return true
+// Current returns the current status of the state.
+func (s *State) Current() Status {
+	s.mut.RLock()
+	defer s.mut.RUnlock()
+	return s.status
+}
+
+// String returns the name of the current status of the state.
+func (s *State) String() string {
+	return s.Current().String()
+}
+
+// SetGauge registers a function that reports the status of the state, such as
+// a metrics gauge. The function is called immediately with the current status,
+// then with the new status on every transition. Since the function is called
+// while holding the state's lock so that statuses are reported in order, it
+// must not call back into the state. Passing nil removes the gauge.
+func (s *State) SetGauge(gauge func(Status)) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	s.gauge = gauge
+	if gauge != nil {
+		gauge(s.status)
+	}
+}
+
+// OnStart registers a function to be called once the state is "Running".
+// Functions are called exactly once, in the order they were registered, by the
+// goroutine that calls Start. If the state is already "Running" or later, the
+// function is called as soon as any earlier hooks have run, which is
+// immediately unless another goroutine is still running them. Functions are
+// never called if the state was aborted without ever running.
+func (s *State) OnStart(fn func()) { s.addHook(StatusRunning, fn) }
+
+// OnStop registers a function to be called once the state is "Stopped".
+// Functions are called exactly once, in the order they were registered, by the
+// goroutine that calls Stop. If the state is already "Stopped" or later, the
+// function is called as soon as any earlier hooks have run, which is
+// immediately unless another goroutine is still running them.
+func (s *State) OnStop(fn func()) { s.addHook(StatusStopped, fn) }
+
+// OnClose registers a function to be called once the state is "Closed".
+// Functions are called exactly once, in the order they were registered, by the
+// goroutine that calls Close. If the state is already "Closed", the function
+// is called as soon as any earlier hooks have run, which is immediately unless
+// another goroutine is still running them.
+func (s *State) OnClose(fn func()) { s.addHook(StatusClosed, fn) }
+
+func (s *State) addHook(st Status, fn func()) {
+	s.mut.Lock()
+	if s.status < st {
+		s.hooks[st] = append(s.hooks[st], fn)
+		s.mut.Unlock()
+		return
+	}
+
+	// Queue behind any hooks from the transition that are still pending
+	if st != StatusRunning || !s.aborted {
+		s.pending = append(s.pending, fn)
+	}
+	s.mut.Unlock()
+
+	s.runPending()
 }
 
 // Running returns a channel that goroutines can block on to
@@ -101,15 +361,63 @@ func (s *State) Stopped() <-chan struct{} { return s.stopped }
 // wait until the stte is "Closed"
 func (s *State) Closed() <-chan struct{} { return s.closed }
 
+// WaitRunning blocks until the state is "Running", returning an error if
+// the context is canceled or times out first.
+func (s *State) WaitRunning(ctx context.Context) error { return wait(ctx, s.started) }
+
+// WaitStopped blocks until the state is "Stopped", returning an error if
+// the context is canceled or times out first.
+func (s *State) WaitStopped(ctx context.Context) error { return wait(ctx, s.stopped) }
+
+// WaitClosed blocks until the state is "Closed", returning an error if
+// the context is canceled or times out first.
+func (s *State) WaitClosed(ctx context.Context) error { return wait(ctx, s.closed) }
+
+func wait(ctx context.Context, ch <-chan struct{}) error {
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Context returns a context that is canceled once the state is "Stopped".
+// The cause of the cancellation is the error passed to StopWithError, or
+// ErrStopped if the state was stopped without an error. Goroutines can select
+// on the context's Done channel rather than having the Stopped() channel
+// passed to them.
+func (s *State) Context() context.Context { return s.ctx }
+
+// StoppingContext returns a context derived from parent that is also canceled
+// once the state is "Stopped", with the same cause as the state's Context.
+// Callers must call the returned cancel function to release resources once
+// the context is no longer needed.
+func (s *State) StoppingContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancelCause(parent)
+	stop := context.AfterFunc(s.ctx, func() {
+		cancel(context.Cause(s.ctx))
+	})
+
+	return ctx, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
 // IfRunning runs the given block only if the state is in the "Running" state.
 func (s *State) IfRunning(fn func()) bool {
 	s.mut.RLock()
 	defer s.mut.RUnlock()
 
-	if s.state != stateStarted {
+	if s.status != StatusRunning {
 		return false
 	}
 
 	fn()
 	return true
 }
+
+var (
+	_ StateObserver = &State{}
+)
@@ -1,6 +1,10 @@
 package lifecycle
 
 import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 
@@ -110,3 +114,354 @@ func TestState_IfRunningReentrant(t *testing.T) {
 	})
 	require.True(t, reentrantRunAllowed)
 }
+
+func TestState_Context(t *testing.T) {
+	st := NewState()
+	ctx := st.Context()
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	defer cancelParent()
+
+	stopping, cancelStopping := st.StoppingContext(parent)
+	defer cancelStopping()
+
+	st.Start()
+	require.NoError(t, ctx.Err())
+	require.NoError(t, stopping.Err())
+
+	st.Stop()
+	select {
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "stopping context not canceled within 5s")
+	case <-stopping.Done():
+	}
+
+	require.ErrorIs(t, ctx.Err(), context.Canceled)
+	require.ErrorIs(t, context.Cause(ctx), ErrStopped)
+	require.ErrorIs(t, context.Cause(stopping), ErrStopped)
+}
+
+func TestState_ContextCause(t *testing.T) {
+	stopErr := errors.New("connection lost")
+
+	st := NewState()
+	stopping, cancelStopping := st.StoppingContext(context.Background())
+	defer cancelStopping()
+
+	st.Start()
+	st.StopWithError(stopErr)
+	select {
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "stopping context not canceled within 5s")
+	case <-stopping.Done():
+	}
+
+	require.Equal(t, stopErr, context.Cause(st.Context()))
+	require.Equal(t, stopErr, context.Cause(stopping))
+	require.NotErrorIs(t, context.Cause(st.Context()), ErrStopped)
+
+	// A clean stop uses ErrStopped as the cause
+	clean := NewState()
+	clean.Start()
+	clean.StopWithError(nil)
+	require.Equal(t, ErrStopped, context.Cause(clean.Context()))
+}
+
+func TestState_StoppingContextParentCanceled(t *testing.T) {
+	st := NewState()
+	st.Start()
+
+	parent, cancelParent := context.WithCancel(context.Background())
+	stopping, cancelStopping := st.StoppingContext(parent)
+	defer cancelStopping()
+
+	cancelParent()
+	select {
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "stopping context not canceled within 5s")
+	case <-stopping.Done():
+	}
+	require.ErrorIs(t, context.Cause(stopping), context.Canceled)
+	require.NoError(t, st.Context().Err())
+}
+
+func TestState_Hooks(t *testing.T) {
+	st := NewState()
+
+	var calls []string
+	st.OnStart(func() { calls = append(calls, "start-1") })
+	st.OnStop(func() { calls = append(calls, "stop-1") })
+	st.OnClose(func() { calls = append(calls, "close-1") })
+	st.OnStart(func() { calls = append(calls, "start-2") })
+
+	// Hooks can use the state, since they are called outside the lock
+	st.OnStop(func() {
+		require.False(t, st.IfRunning(func() {}))
+		calls = append(calls, "stop-2")
+	})
+
+	require.True(t, st.Start())
+	require.False(t, st.Start())
+	require.Equal(t, []string{"start-1", "start-2"}, calls)
+
+	// Hooks for past transitions run immediately
+	st.OnStart(func() { calls = append(calls, "start-3") })
+	require.Equal(t, []string{"start-1", "start-2", "start-3"}, calls)
+
+	require.True(t, st.Stop())
+	require.False(t, st.Stop())
+	require.True(t, st.Close())
+	require.False(t, st.Close())
+	require.Equal(t, []string{"start-1", "start-2", "start-3", "stop-1", "stop-2", "close-1"}, calls)
+}
+
+func TestState_LateHooksRunInOrder(t *testing.T) {
+	st := NewState()
+
+	var (
+		mut   sync.Mutex
+		calls []string
+	)
+
+	record := func(name string) {
+		mut.Lock()
+		defer mut.Unlock()
+		calls = append(calls, name)
+	}
+
+	entered, release := make(chan struct{}), make(chan struct{})
+	st.OnStart(func() {
+		close(entered)
+		<-release
+		record("start-1")
+	})
+
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		st.Start()
+	}()
+
+	// Registered once Running, while the first hook is still blocked
+	select {
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "start hook not called within 5s")
+	case <-entered:
+	}
+	st.OnStart(func() { record("start-2") })
+
+	close(release)
+	select {
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "start not complete within 5s")
+	case <-started:
+	}
+
+	mut.Lock()
+	defer mut.Unlock()
+	require.Equal(t, []string{"start-1", "start-2"}, calls)
+}
+
+func TestState_Abort(t *testing.T) {
+	st := NewState()
+	abortErr := errors.New("failed to start")
+
+	var calls []string
+	st.OnStart(func() { calls = append(calls, "start") })
+	st.OnStop(func() { calls = append(calls, "stop") })
+	st.OnClose(func() { calls = append(calls, "close") })
+
+	require.True(t, st.Abort(abortErr))
+	require.False(t, st.Abort(nil))
+	require.False(t, st.Start())
+	require.Equal(t, StatusClosed, st.Current())
+	require.Equal(t, []string{"stop", "close"}, calls)
+
+	// Never running, so later OnStart hooks are not called either
+	st.OnStart(func() { calls = append(calls, "start") })
+	require.Equal(t, []string{"stop", "close"}, calls)
+
+	<-st.Stopped()
+	<-st.Closed()
+	require.Equal(t, abortErr, st.Err())
+	require.Equal(t, abortErr, context.Cause(st.Context()))
+
+	select {
+	case <-st.Running():
+		require.Fail(t, "aborted state should never be running")
+	default:
+	}
+
+	// Aborting is only possible before starting
+	started := NewState()
+	require.True(t, started.Start())
+	require.False(t, started.Abort(nil))
+}
+
+func TestState_Wait(t *testing.T) {
+	st := NewState()
+
+	// Times out waiting for transitions that have not happened
+	for _, waitFn := range []func(context.Context) error{st.WaitRunning, st.WaitStopped, st.WaitClosed} {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		require.ErrorIs(t, waitFn(ctx), context.DeadlineExceeded)
+		cancel()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancel()
+
+	go func() {
+		st.Start()
+		st.Stop()
+		st.Close()
+	}()
+
+	require.NoError(t, st.WaitClosed(ctx))
+	require.NoError(t, st.WaitRunning(ctx))
+	require.NoError(t, st.WaitStopped(ctx))
+
+	// Fails immediately with an already canceled context
+	canceled, cancelNow := context.WithCancel(context.Background())
+	cancelNow()
+	require.ErrorIs(t, NewState().WaitRunning(canceled), context.Canceled)
+}
+
+func TestState_Errors(t *testing.T) {
+	stopErr := errors.New("connection lost")
+	closeErr := errors.New("flush failed")
+
+	st := NewState()
+	require.False(t, st.StopWithError(stopErr))
+	require.NoError(t, st.Err())
+
+	st.Start()
+	require.True(t, st.StopWithError(stopErr))
+	require.False(t, st.StopWithError(closeErr))
+	require.ErrorIs(t, st.Err(), stopErr)
+	require.ErrorIs(t, context.Cause(st.Context()), stopErr)
+
+	require.True(t, st.CloseWithError(closeErr))
+	require.ErrorIs(t, st.Err(), stopErr)
+	require.ErrorIs(t, st.Err(), closeErr)
+}
+
+func TestState_CleanShutdown(t *testing.T) {
+	st := NewState()
+	st.Start()
+	st.Stop()
+	st.Close()
+	require.NoError(t, st.Err())
+	require.ErrorIs(t, context.Cause(st.Context()), ErrStopped)
+}
+
+func TestState_Current(t *testing.T) {
+	st := NewState()
+
+	var reported []Status
+	st.SetGauge(func(status Status) {
+		reported = append(reported, status)
+	})
+
+	require.Equal(t, StatusInit, st.Current())
+	require.Equal(t, "Init", st.String())
+
+	st.Start()
+	require.Equal(t, StatusRunning, st.Current())
+	require.Equal(t, "Running", st.String())
+
+	st.Stop()
+	require.Equal(t, StatusStopped, st.Current())
+	require.Equal(t, "Stopped", st.String())
+
+	st.Close()
+	require.Equal(t, StatusClosed, st.Current())
+	require.Equal(t, "Closed", st.String())
+
+	require.Equal(t, []Status{StatusInit, StatusRunning, StatusStopped, StatusClosed}, reported)
+	require.Equal(t, "Status(12)", Status(12).String())
+}
+
+func TestState_Subscribe(t *testing.T) {
+	st := NewState()
+
+	var all, untilStopped []Transition
+	st.Subscribe(func(tr Transition) {
+		all = append(all, tr)
+	})
+
+	unsubscribe := st.Subscribe(func(tr Transition) {
+		untilStopped = append(untilStopped, tr)
+	})
+
+	before := time.Now()
+	st.Start()
+	st.Stop()
+	unsubscribe()
+	st.Close()
+
+	require.Len(t, all, 3)
+	for i, expected := range []struct{ from, to Status }{
+		{StatusInit, StatusRunning},
+		{StatusRunning, StatusStopped},
+		{StatusStopped, StatusClosed},
+	} {
+		require.Equal(t, expected.from, all[i].From)
+		require.Equal(t, expected.to, all[i].To)
+		require.False(t, all[i].Time.Before(before))
+	}
+
+	require.Equal(t, all[:2], untilStopped)
+
+	// Failed transitions are not reported
+	st.Start()
+	require.Len(t, all, 3)
+}
+
+func TestState_SubscribeConcurrentTransitions(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		st := NewState()
+
+		var (
+			mut         sync.Mutex
+			transitions []Transition
+		)
+
+		st.Subscribe(func(tr Transition) {
+			if tr.To == StatusStopped {
+				// Give a concurrent Close the chance to overtake the Stop
+				time.Sleep(time.Millisecond)
+			}
+
+			mut.Lock()
+			defer mut.Unlock()
+			transitions = append(transitions, tr)
+		})
+
+		st.Start()
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			st.Stop()
+		}()
+		go func() {
+			defer wg.Done()
+			for !st.Close() {
+				runtime.Gosched()
+			}
+		}()
+		wg.Wait()
+
+		mut.Lock()
+		require.Len(t, transitions, 3)
+		for i, to := range []Status{StatusRunning, StatusStopped, StatusClosed} {
+			require.Equal(t, to, transitions[i].To)
+			if i > 0 {
+				require.False(t, transitions[i].Time.Before(transitions[i-1].Time))
+			}
+		}
+		mut.Unlock()
+	}
+}
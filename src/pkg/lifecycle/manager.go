@@ -0,0 +1,121 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// A Component is part of an application that needs to be started and stopped.
+type Component interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// A Manager starts and stops a set of components as a unit. Components are
+// started in the order they were added, and stopped in the reverse order, so
+// that a component can depend on the components added before it.
+type Manager struct {
+	mut        sync.Mutex
+	state      *State
+	components []Component
+	started    []Component
+}
+
+// NewManager creates a new Manager for the given components.
+func NewManager(components ...Component) *Manager {
+	return &Manager{
+		state:      NewState(),
+		components: components,
+	}
+}
+
+// Add adds components to the manager. Components can only be added before
+// the manager is started.
+func (m *Manager) Add(components ...Component) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.state.Current() != StatusInit {
+		return errors.New("cannot add components to a manager that has already started")
+	}
+
+	m.components = append(m.components, components...)
+	return nil
+}
+
+// State returns the combined state of the managed components. The state is
+// Running once all components have started, Stopped once the manager starts
+// stopping them, and Closed once all components have stopped. The state is
+// read-only, since only the manager's Start and Stop methods can start and
+// stop the components.
+func (m *Manager) State() StateObserver {
+	// Wrap the state so callers cannot type assert their way back to it
+	return stateObserver{m.state}
+}
+
+// stateObserver exposes only the read-only methods of a State.
+type stateObserver struct {
+	StateObserver
+}
+
+// Start starts all components in the order they were added. If a component
+// fails to start, the components that have already started are stopped in
+// reverse order, and the manager is left Closed. Returns the errors from
+// starting and stopping the components.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if m.state.Current() != StatusInit {
+		return errors.New("manager has already been started")
+	}
+
+	for i, c := range m.components {
+		if err := c.Start(ctx); err != nil {
+			err = fmt.Errorf("unable to start component %d (%T): %w", i, c, err)
+
+			err = multierr.Append(err, m.stopAll(ctx))
+			m.state.Abort(err)
+			return err
+		}
+
+		m.started = append(m.started, c)
+	}
+
+	m.state.Start()
+	return nil
+}
+
+// Stop stops all started components in the reverse of the order they were
+// started, returning the combined errors from all components. Stopping
+// continues even if some components fail to stop. Does nothing if the
+// manager is not running.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+
+	if !m.state.Stop() {
+		return nil
+	}
+
+	err := m.stopAll(ctx)
+	m.state.Close()
+	return err
+}
+
+func (m *Manager) stopAll(ctx context.Context) error {
+	var err error
+	for i := len(m.started) - 1; i >= 0; i-- {
+		c := m.started[i]
+		if stopErr := c.Stop(ctx); stopErr != nil {
+			err = multierr.Append(err, fmt.Errorf("unable to stop component %d (%T): %w", i, c, stopErr))
+		}
+	}
+
+	m.started = nil
+	return err
+}
@@ -0,0 +1,124 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+type testComponent struct {
+	name     string
+	calls    *[]string
+	startErr error
+	stopErr  error
+}
+
+func (c *testComponent) Start(_ context.Context) error {
+	*c.calls = append(*c.calls, "start "+c.name)
+	return c.startErr
+}
+
+func (c *testComponent) Stop(_ context.Context) error {
+	*c.calls = append(*c.calls, "stop "+c.name)
+	return c.stopErr
+}
+
+func TestManager(t *testing.T) {
+	var calls []string
+	m := NewManager(
+		&testComponent{name: "db", calls: &calls},
+		&testComponent{name: "cache", calls: &calls},
+	)
+	require.NoError(t, m.Add(&testComponent{name: "server", calls: &calls}))
+
+	require.NoError(t, m.Start(context.Background()))
+	require.Error(t, m.Start(context.Background()))
+	require.Error(t, m.Add(&testComponent{name: "late", calls: &calls}))
+
+	<-m.State().Running()
+	require.True(t, m.State().IfRunning(func() {}))
+
+	require.NoError(t, m.Stop(context.Background()))
+	require.NoError(t, m.Stop(context.Background()))
+	<-m.State().Closed()
+
+	require.Equal(t, []string{
+		"start db", "start cache", "start server",
+		"stop server", "stop cache", "stop db",
+	}, calls)
+}
+
+func TestManager_StateIsReadOnly(t *testing.T) {
+	var calls []string
+	m := NewManager(&testComponent{name: "db", calls: &calls})
+	require.NoError(t, m.Start(context.Background()))
+
+	_, ok := m.State().(interface{ Stop() bool })
+	require.False(t, ok, "manager state should not be stoppable by callers")
+
+	require.NoError(t, m.Stop(context.Background()))
+	require.Equal(t, []string{"start db", "stop db"}, calls)
+	require.Equal(t, StatusClosed, m.State().Current())
+}
+
+func TestManager_StartFailure(t *testing.T) {
+	var (
+		calls    []string
+		startErr = errors.New("cache unavailable")
+		stopErr  = errors.New("db stuck")
+	)
+
+	m := NewManager(
+		&testComponent{name: "db", calls: &calls, stopErr: stopErr},
+		&testComponent{name: "queue", calls: &calls},
+		&testComponent{name: "cache", calls: &calls, startErr: startErr},
+		&testComponent{name: "server", calls: &calls},
+	)
+
+	var transitions []Transition
+	m.State().Subscribe(func(tr Transition) { transitions = append(transitions, tr) })
+	m.State().OnStart(func() { require.Fail(t, "manager should never be running") })
+
+	err := m.Start(context.Background())
+	require.ErrorIs(t, err, startErr)
+	require.ErrorIs(t, err, stopErr)
+	require.Len(t, multierr.Errors(err), 2)
+
+	require.Equal(t, []string{
+		"start db", "start queue", "start cache",
+		"stop queue", "stop db",
+	}, calls)
+
+	<-m.State().Closed()
+	require.NoError(t, m.Stop(context.Background()))
+	require.Equal(t, err, m.State().Err())
+
+	require.Len(t, transitions, 1)
+	require.Equal(t, StatusInit, transitions[0].From)
+	require.Equal(t, StatusClosed, transitions[0].To)
+
+	m.State().OnStart(func() { require.Fail(t, "manager should never be running") })
+}
+
+func TestManager_StopErrors(t *testing.T) {
+	var (
+		calls []string
+		err1  = errors.New("first")
+		err2  = errors.New("second")
+	)
+
+	m := NewManager(
+		&testComponent{name: "a", calls: &calls, stopErr: err1},
+		&testComponent{name: "b", calls: &calls},
+		&testComponent{name: "c", calls: &calls, stopErr: err2},
+	)
+
+	require.NoError(t, m.Start(context.Background()))
+	err := m.Stop(context.Background())
+	require.ErrorIs(t, err, err1)
+	require.ErrorIs(t, err, err2)
+	require.Equal(t, []string{"start a", "start b", "start c", "stop c", "stop b", "stop a"}, calls)
+}
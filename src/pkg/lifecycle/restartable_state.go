@@ -0,0 +1,83 @@
+package lifecycle
+
+import (
+	"sync"
+)
+
+// A RestartableState is a State that can be restarted once stopped, for
+// components that are paused and resumed rather than created fresh each time.
+// Each run is a separate generation with its own State, so goroutines started
+// for a run can block on the channels of that run's State without being
+// confused by later runs.
+//
+// Restarting a Stopped state closes the State of the previous generation
+// before starting a new one. Calling Close on a Stopped state closes it
+// for good, after which it can no longer be restarted.
+type RestartableState struct {
+	mut        sync.Mutex
+	generation int
+	current    *State
+}
+
+// NewRestartableState creates a new RestartableState in the Init state.
+func NewRestartableState() *RestartableState {
+	return &RestartableState{
+		current: NewState(),
+	}
+}
+
+// Start transitions to the "Running" state, either from the "Init" state or
+// from the "Stopped" state, in which case a new generation is started.
+func (s *RestartableState) Start() bool {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	switch s.current.Current() {
+	case StatusInit:
+	case StatusStopped:
+		s.current.Close()
+		s.current = NewState()
+	default:
+		return false
+	}
+
+	s.generation++
+	return s.current.Start()
+}
+
+// Stop transitions the current generation from the "Running" state to the
+// "Stopped" state.
+func (s *RestartableState) Stop() bool {
+	return s.State().Stop()
+}
+
+// Close transitions the current generation from the "Stopped" state to the
+// "Closed" state, after which the state can no longer be restarted.
+func (s *RestartableState) Close() bool {
+	return s.State().Close()
+}
+
+// Generation returns the number of times the state has been started, and the
+// State of the current generation. The generation is 0 before the first start.
+func (s *RestartableState) Generation() (int, *State) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return s.generation, s.current
+}
+
+// State returns the State of the current generation.
+func (s *RestartableState) State() *State {
+	_, st := s.Generation()
+	return st
+}
+
+// Current returns the status of the current generation.
+func (s *RestartableState) Current() Status {
+	return s.State().Current()
+}
+
+// IfRunning runs the given block only if the current generation is in the
+// "Running" state.
+func (s *RestartableState) IfRunning(fn func()) bool {
+	return s.State().IfRunning(fn)
+}
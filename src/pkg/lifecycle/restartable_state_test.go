@@ -0,0 +1,52 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestartableState(t *testing.T) {
+	st := NewRestartableState()
+
+	gen, initial := st.Generation()
+	require.Equal(t, 0, gen)
+	require.Equal(t, StatusInit, st.Current())
+	require.False(t, st.Stop())
+
+	// First run
+	require.True(t, st.Start())
+	require.False(t, st.Start())
+	gen, first := st.Generation()
+	require.Equal(t, 1, gen)
+	require.Same(t, initial, first)
+	require.True(t, st.IfRunning(func() {}))
+
+	require.True(t, st.Stop())
+	require.False(t, st.IfRunning(func() {}))
+	<-first.Stopped()
+
+	// Restarting closes the previous generation and starts a new one
+	require.True(t, st.Start())
+	gen, second := st.Generation()
+	require.Equal(t, 2, gen)
+	require.NotSame(t, first, second)
+	require.Equal(t, StatusClosed, first.Current())
+	require.Equal(t, StatusRunning, second.Current())
+	<-second.Running()
+
+	select {
+	case <-second.Stopped():
+		require.Fail(t, "new generation should not be stopped")
+	default:
+	}
+
+	// Closing is final
+	require.True(t, st.Stop())
+	require.True(t, st.Close())
+	require.False(t, st.Start())
+	require.Equal(t, StatusClosed, st.Current())
+
+	gen, _ = st.Generation()
+	require.Equal(t, 2, gen)
+}
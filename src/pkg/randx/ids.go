@@ -0,0 +1,231 @@
+package randx
+
+import (
+	cryptrand "crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mmihic/golib/src/pkg/timex"
+)
+
+// idReader is the source of randomness for generated IDs.
+var idReader io.Reader = cryptrand.Reader
+
+func readID(b []byte) error {
+	if _, err := io.ReadFull(idReader, b); err != nil {
+		return fmt.Errorf("unable to read random bytes for ID: %w", err)
+	}
+
+	return nil
+}
+
+// must returns the ID, panicking if it could not be generated.
+func must[T any](id T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+
+	return id
+}
+
+func putTimestamp(b []byte, t time.Time) {
+	ms := uint64(t.UnixMilli())
+	b[0], b[1], b[2] = byte(ms>>40), byte(ms>>32), byte(ms>>24)
+	b[3], b[4], b[5] = byte(ms>>16), byte(ms>>8), byte(ms)
+}
+
+func timestamp(b []byte) time.Time {
+	ms := uint64(b[0])<<40 | uint64(b[1])<<32 | uint64(b[2])<<24 |
+		uint64(b[3])<<16 | uint64(b[4])<<8 | uint64(b[5])
+	return time.UnixMilli(int64(ms))
+}
+
+// A UUID is an RFC 9562 universally unique identifier.
+type UUID [16]byte
+
+// UUID4 returns a new random (version 4) UUID. Returns an error if the
+// random bytes for the UUID cannot be read.
+func UUID4() (UUID, error) {
+	var u UUID
+	if err := readID(u[:]); err != nil {
+		return UUID{}, err
+	}
+
+	return u.withVersion(4), nil
+}
+
+// MustUUID4 returns a new random (version 4) UUID, panicking if the
+// random bytes for the UUID cannot be read.
+func MustUUID4() UUID {
+	return must(UUID4())
+}
+
+// UUID7 returns a new time-ordered (version 7) UUID, using the clock for
+// the timestamp. UUIDs generated in later milliseconds sort after those
+// generated in earlier milliseconds. Returns an error if the random bytes
+// for the UUID cannot be read.
+func UUID7(clock timex.Clock) (UUID, error) {
+	var u UUID
+	if err := readID(u[6:]); err != nil {
+		return UUID{}, err
+	}
+
+	putTimestamp(u[:], clock.Now())
+	return u.withVersion(7), nil
+}
+
+// MustUUID7 returns a new time-ordered (version 7) UUID, panicking if the
+// random bytes for the UUID cannot be read.
+func MustUUID7(clock timex.Clock) UUID {
+	return must(UUID7(clock))
+}
+
+func (u UUID) withVersion(version byte) UUID {
+	u[6] = (u[6] & 0x0f) | version<<4
+	u[8] = (u[8] & 0x3f) | 0x80 // RFC 9562 variant
+	return u
+}
+
+// ParseUUID parses a UUID in the standard xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if len(s) != 36 || s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+		return u, fmt.Errorf("invalid UUID '%s'", s)
+	}
+
+	digits := s[0:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+	if _, err := hex.Decode(u[:], []byte(digits)); err != nil {
+		return u, fmt.Errorf("invalid UUID '%s': %w", s, err)
+	}
+
+	return u, nil
+}
+
+// Version returns the version of the UUID.
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// String returns the UUID in the standard xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form.
+func (u UUID) String() string {
+	var b [36]byte
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b[:])
+}
+
+// MarshalText marshals the UUID in its standard string form.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText unmarshals a UUID from its standard string form.
+func (u *UUID) UnmarshalText(b []byte) error {
+	parsed, err := ParseUUID(string(b))
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+	return nil
+}
+
+// crockford is the Crockford base32 alphabet used to encode ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// A ULID is a universally unique lexicographically sortable identifier,
+// made up of a 48-bit millisecond timestamp followed by 80 random bits.
+type ULID [16]byte
+
+// NewULID returns a new ULID, using the clock for the timestamp. Returns an
+// error if the random bytes for the ULID cannot be read.
+func NewULID(clock timex.Clock) (ULID, error) {
+	var id ULID
+	if err := readID(id[6:]); err != nil {
+		return ULID{}, err
+	}
+
+	putTimestamp(id[:], clock.Now())
+	return id, nil
+}
+
+// MustNewULID returns a new ULID, panicking if the random bytes for the
+// ULID cannot be read.
+func MustNewULID(clock timex.Clock) ULID {
+	return must(NewULID(clock))
+}
+
+// ParseULID parses a ULID from its 26 character Crockford base32 form.
+// Parsing is case-insensitive.
+func ParseULID(s string) (ULID, error) {
+	var id ULID
+	if len(s) != 26 {
+		return id, fmt.Errorf("invalid ULID '%s': must be 26 characters", s)
+	}
+
+	// The first character only holds 3 bits, since 26 characters hold 130 bits
+	if s[0] > '7' {
+		return id, fmt.Errorf("invalid ULID '%s': overflows 128 bits", s)
+	}
+
+	var hi, lo uint64
+	for _, c := range strings.ToUpper(s) {
+		v := strings.IndexRune(crockford, c)
+		if v < 0 {
+			return id, fmt.Errorf("invalid ULID '%s': invalid character '%c'", s, c)
+		}
+
+		hi = hi<<5 | lo>>59
+		lo = lo<<5 | uint64(v)
+	}
+
+	binary.BigEndian.PutUint64(id[:8], hi)
+	binary.BigEndian.PutUint64(id[8:], lo)
+	return id, nil
+}
+
+// Time returns the timestamp of the ULID, to the millisecond.
+func (id ULID) Time() time.Time {
+	return timestamp(id[:])
+}
+
+// String returns the ULID in its 26 character Crockford base32 form.
+func (id ULID) String() string {
+	hi, lo := binary.BigEndian.Uint64(id[:8]), binary.BigEndian.Uint64(id[8:])
+
+	var b [26]byte
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(b[:])
+}
+
+// MarshalText marshals the ULID in its Crockford base32 form.
+func (id ULID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText unmarshals a ULID from its Crockford base32 form.
+func (id *ULID) UnmarshalText(b []byte) error {
+	parsed, err := ParseULID(string(b))
+	if err != nil {
+		return err
+	}
+
+	*id = parsed
+	return nil
+}
@@ -0,0 +1,153 @@
+package randx
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+// withIDReader replaces the source of randomness for IDs for the duration of a test.
+func withIDReader(t *testing.T, seed int64) {
+	old := idReader
+	idReader = rand.New(rand.NewSource(seed))
+	t.Cleanup(func() {
+		idReader = old
+	})
+}
+
+func TestUUID4(t *testing.T) {
+	withIDReader(t, 56746)
+
+	u, err := UUID4()
+	require.NoError(t, err)
+	assert.Equal(t, "811a6365-e3cc-42ce-8ec4-92f0b76c3b61", u.String())
+	assert.Equal(t, 4, u.Version())
+	assert.NotEqual(t, u, MustUUID4())
+}
+
+func TestUUID7(t *testing.T) {
+	withIDReader(t, 56746)
+
+	clock := fixedClock(time.Date(2024, time.March, 5, 12, 30, 0, 0, time.UTC))
+	u, err := UUID7(clock)
+	require.NoError(t, err)
+	assert.Equal(t, "018e0e97-9940-711a-a365-e3cc22ce0ec4", u.String())
+	assert.Equal(t, 7, u.Version())
+
+	// Later UUIDs sort after earlier ones
+	later := MustUUID7(fixedClock(time.Time(clock).Add(time.Millisecond)))
+	assert.Less(t, u.String(), later.String())
+}
+
+func TestIDReadErrors(t *testing.T) {
+	old := idReader
+	idReader = failingReader{err: errors.New("no entropy")}
+	t.Cleanup(func() {
+		idReader = old
+	})
+
+	clock := fixedClock(time.Date(2024, time.March, 5, 12, 30, 0, 0, time.UTC))
+
+	_, err := UUID4()
+	assert.EqualError(t, err, "unable to read random bytes for ID: no entropy")
+	_, err = UUID7(clock)
+	assert.EqualError(t, err, "unable to read random bytes for ID: no entropy")
+	_, err = NewULID(clock)
+	assert.EqualError(t, err, "unable to read random bytes for ID: no entropy")
+
+	assert.Panics(t, func() { MustUUID4() })
+	assert.Panics(t, func() { MustUUID7(clock) })
+	assert.Panics(t, func() { MustNewULID(clock) })
+}
+
+func TestParseUUID(t *testing.T) {
+	u, err := ParseUUID("6BA7B810-9dad-11d1-80b4-00c04fd430c8")
+	require.NoError(t, err)
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", u.String())
+	assert.Equal(t, 1, u.Version())
+
+	for _, s := range []string{
+		"",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b8109dad-11d1-80b4-00c04fd430c8a",
+		"6ba7b810-9dad-11d1-80b4-00c04fd430cz",
+	} {
+		_, err := ParseUUID(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestUUID_JSON(t *testing.T) {
+	var val struct {
+		ID UUID `json:"id"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`), &val))
+	assert.Equal(t, "6ba7b810-9dad-11d1-80b4-00c04fd430c8", val.ID.String())
+
+	b, err := json.Marshal(val)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"6ba7b810-9dad-11d1-80b4-00c04fd430c8"}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`{"id":"nope"}`), &val))
+}
+
+func TestULID(t *testing.T) {
+	withIDReader(t, 56746)
+
+	now := time.Date(2024, time.March, 5, 12, 30, 0, 0, time.UTC)
+	id, err := NewULID(fixedClock(now))
+	require.NoError(t, err)
+	assert.Equal(t, "01HR79F6A0G4D66SF3SGHCW3P4", id.String())
+	assert.Equal(t, now, id.Time().UTC())
+
+	parsed, err := ParseULID(id.String())
+	require.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	// Later ULIDs sort after earlier ones
+	later := MustNewULID(fixedClock(now.Add(time.Millisecond)))
+	assert.Less(t, id.String(), later.String())
+}
+
+func TestParseULID(t *testing.T) {
+	id, err := ParseULID("01arz3ndektsv4rrffq69g5fav")
+	require.NoError(t, err)
+	assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", id.String())
+	assert.Equal(t, int64(1469922850259), id.Time().UnixMilli())
+
+	maxID, err := ParseULID("7ZZZZZZZZZZZZZZZZZZZZZZZZZ")
+	require.NoError(t, err)
+	assert.Equal(t, ULID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, maxID)
+
+	for _, s := range []string{
+		"",
+		"01ARZ3NDEKTSV4RRFFQ69G5FA",
+		"81ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAU",
+	} {
+		_, err := ParseULID(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestULID_JSON(t *testing.T) {
+	var val struct {
+		ID ULID `json:"id"`
+	}
+
+	require.NoError(t, json.Unmarshal([]byte(`{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`), &val))
+	b, err := json.Marshal(val)
+	require.NoError(t, err)
+	assert.Equal(t, `{"id":"01ARZ3NDEKTSV4RRFFQ69G5FAV"}`, string(b))
+}
@@ -0,0 +1,61 @@
+package randx
+
+import (
+	"math"
+	"time"
+)
+
+// Jitter randomizes a duration by up to a fraction of its length in either
+// direction, e.g. a fraction of 0.1 returns a duration within 10% of d.
+// The fraction is clamped to [0, 1], so the result is never negative.
+func Jitter(r Rand, d time.Duration, fraction float64) time.Duration {
+	fraction = max(0, min(fraction, 1))
+	offset := (2*r.Float64() - 1) * fraction * float64(d)
+	return d + time.Duration(offset)
+}
+
+// Between returns a random duration in the half-open interval [lo, hi).
+// Returns lo if hi is not after lo.
+func Between(r Rand, lo, hi time.Duration) time.Duration {
+	if hi <= lo {
+		return lo
+	}
+
+	span := uint64(hi) - uint64(lo)
+	if span <= math.MaxInt64 {
+		return lo + time.Duration(r.Int63n(int64(span)))
+	}
+
+	// The span is wider than an int64 when lo is negative and hi positive, so
+	// draw 64 random bits and reject those that fall outside it. More than half
+	// of all draws fall inside the span, so this terminates quickly.
+	for {
+		if v := uint64(r.Int63())<<32 ^ uint64(r.Int63()); v < span {
+			return time.Duration(uint64(lo) + v)
+		}
+	}
+}
+
+// ExpJitter returns a randomized delay for a retry with exponential backoff,
+// using "full jitter": a random duration between zero and base * 2^attempt,
+// capped at maxDelay. The first attempt is attempt 0.
+func ExpJitter(r Rand, base, maxDelay time.Duration, attempt int) time.Duration {
+	ceiling := maxDelay
+	if attempt >= 0 && attempt < 63 {
+		// Guard against overflow for large attempts
+		if d := base << attempt; d >= 0 && d>>attempt == base {
+			ceiling = min(d, maxDelay)
+		}
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	if ceiling == math.MaxInt64 {
+		// Int63n cannot include MaxInt64 itself; Int63 covers the full range
+		return time.Duration(r.Int63())
+	}
+
+	return time.Duration(r.Int63n(int64(ceiling) + 1))
+}
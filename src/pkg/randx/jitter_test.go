@@ -0,0 +1,101 @@
+package randx
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJitter(t *testing.T) {
+	r := New(rand.NewSource(56746)) // fixed seed
+	for _, tt := range []struct {
+		name     string
+		fraction float64
+		lo, hi   time.Duration
+	}{
+		{"tenth", 0.1, time.Second * 9, time.Second * 11},
+		{"half", 0.5, time.Second * 5, time.Second * 15},
+		{"none", 0, time.Second * 10, time.Second * 10},
+		{"clamped", 5, 0, time.Second * 20},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				d := Jitter(r, time.Second*10, tt.fraction)
+				assert.GreaterOrEqual(t, d, tt.lo)
+				assert.LessOrEqual(t, d, tt.hi)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	r := New(rand.NewSource(56746)) // fixed seed
+	for i := 0; i < 1000; i++ {
+		d := Between(r, time.Second, time.Second*2)
+		assert.GreaterOrEqual(t, d, time.Second)
+		assert.Less(t, d, time.Second*2)
+	}
+
+	assert.Equal(t, time.Second, Between(r, time.Second, time.Second))
+	assert.Equal(t, time.Second*2, Between(r, time.Second*2, time.Second))
+
+	// Spans wider than an int64
+	for _, tt := range []struct {
+		name   string
+		lo, hi time.Duration
+	}{
+		{"full range", math.MinInt64, math.MaxInt64},
+		{"negative to max", -time.Second, math.MaxInt64},
+		{"min to positive", math.MinInt64, time.Second},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 1000; i++ {
+				d := Between(r, tt.lo, tt.hi)
+				assert.GreaterOrEqual(t, d, tt.lo)
+				assert.Less(t, d, tt.hi)
+			}
+		})
+	}
+}
+
+func TestExpJitter(t *testing.T) {
+	r := New(rand.NewSource(56746)) // fixed seed
+	for _, tt := range []struct {
+		name    string
+		attempt int
+		ceiling time.Duration
+	}{
+		{"first attempt", 0, time.Millisecond * 100},
+		{"third attempt", 2, time.Millisecond * 400},
+		{"capped", 10, time.Second * 5},
+		{"overflow", 100, time.Second * 5},
+		{"huge shift", 62, time.Second * 5},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var maxSeen time.Duration
+			for i := 0; i < 1000; i++ {
+				d := ExpJitter(r, time.Millisecond*100, time.Second*5, tt.attempt)
+				assert.GreaterOrEqual(t, d, time.Duration(0))
+				assert.LessOrEqual(t, d, tt.ceiling)
+				maxSeen = max(maxSeen, d)
+			}
+
+			// With enough samples, the delays should cover most of the range
+			assert.Greater(t, maxSeen, tt.ceiling/2)
+		})
+	}
+
+	assert.Equal(t, time.Duration(0), ExpJitter(r, 0, time.Second, 3))
+
+	// An unbounded maximum delay
+	assert.NotPanics(t, func() {
+		for _, attempt := range []int{0, 10, 62, 63, 100} {
+			d := ExpJitter(r, time.Second, math.MaxInt64, attempt)
+			assert.GreaterOrEqual(t, d, time.Duration(0))
+		}
+		ExpJitter(r, math.MaxInt64, math.MaxInt64, 0)
+	})
+}
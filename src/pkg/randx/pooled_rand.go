@@ -0,0 +1,99 @@
+package randx
+
+import (
+	cryptrand "crypto/rand"
+	"encoding/binary"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// NewPooled returns a Rand that is safe for concurrent use. Rather than
+// serializing goroutines on a single locked source, it keeps a pool of
+// independently seeded sources, so concurrent callers rarely contend.
+// Since the source used for each call varies, the values are not
+// reproducible; use New with a fixed seed for deterministic tests.
+func NewPooled() Rand {
+	p := &pooledRand{}
+	p.pool.New = func() any {
+		return New(rand.NewSource(p.nextSeed()))
+	}
+	return p
+}
+
+type pooledRand struct {
+	pool  sync.Pool
+	seeds atomic.Int64
+}
+
+// nextSeed returns a seed for a new source. Seeds come from crypto/rand when
+// possible, falling back to the time, and are mixed with a counter so that
+// sources created at the same moment still differ.
+func (p *pooledRand) nextSeed() int64 {
+	var b [8]byte
+	seed := time.Now().UnixNano()
+	if _, err := cryptrand.Read(b[:]); err == nil {
+		seed = int64(binary.LittleEndian.Uint64(b[:]))
+	}
+
+	return seed ^ p.seeds.Add(0x5851f42d4c957f2d)
+}
+
+func withPooled[T any](p *pooledRand, fn func(r Rand) T) T {
+	r := p.pool.Get().(Rand)
+	defer p.pool.Put(r)
+	return fn(r)
+}
+
+func (p *pooledRand) String(n int, alphabet []rune) string {
+	return withPooled(p, func(r Rand) string { return r.String(n, alphabet) })
+}
+
+func (p *pooledRand) Int() int {
+	return withPooled(p, func(r Rand) int { return r.Int() })
+}
+
+func (p *pooledRand) Intn(n int) int {
+	return withPooled(p, func(r Rand) int { return r.Intn(n) })
+}
+
+func (p *pooledRand) Int31() int32 {
+	return withPooled(p, func(r Rand) int32 { return r.Int31() })
+}
+
+func (p *pooledRand) Int31n(n int32) int32 {
+	return withPooled(p, func(r Rand) int32 { return r.Int31n(n) })
+}
+
+func (p *pooledRand) Int63() int64 {
+	return withPooled(p, func(r Rand) int64 { return r.Int63() })
+}
+
+func (p *pooledRand) Int63n(n int64) int64 {
+	return withPooled(p, func(r Rand) int64 { return r.Int63n(n) })
+}
+
+func (p *pooledRand) Float32() float32 {
+	return withPooled(p, func(r Rand) float32 { return r.Float32() })
+}
+
+func (p *pooledRand) Float64() float64 {
+	return withPooled(p, func(r Rand) float64 { return r.Float64() })
+}
+
+func (p *pooledRand) NormFloat64() float64 {
+	return withPooled(p, func(r Rand) float64 { return r.NormFloat64() })
+}
+
+func (p *pooledRand) ExpFloat64() float64 {
+	return withPooled(p, func(r Rand) float64 { return r.ExpFloat64() })
+}
+
+func (p *pooledRand) Read(b []byte) (int, error) {
+	r := p.pool.Get().(Rand)
+	defer p.pool.Put(r)
+	return r.Read(b)
+}
+
+var _ Rand = &pooledRand{}
@@ -0,0 +1,45 @@
+package randx
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPooled(t *testing.T) {
+	r := NewPooled()
+	for i := 0; i < 100; i++ {
+		assert.GreaterOrEqual(t, r.Int(), 0)
+		assert.Less(t, r.Intn(10), 10)
+		assert.Less(t, r.Int31n(10), int32(10))
+		assert.Less(t, r.Int63n(10), int64(10))
+		assert.GreaterOrEqual(t, r.Int31(), int32(0))
+		assert.GreaterOrEqual(t, r.Int63(), int64(0))
+		assert.Less(t, r.Float32(), float32(1))
+		assert.Less(t, r.Float64(), 1.0)
+	}
+
+	b := make([]byte, 16)
+	n, err := r.Read(b)
+	assert.NoError(t, err)
+	assert.Equal(t, 16, n)
+	assert.Len(t, r.String(8, nil), 8)
+}
+
+func TestDefault_Concurrent(t *testing.T) {
+	// Run with -race to check that the default RNG is safe for concurrent use
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.Len(t, String(10, nil), 10)
+				Shuffle(Default(), []int{1, 2, 3, 4})
+			}
+		}()
+	}
+
+	wg.Wait()
+}
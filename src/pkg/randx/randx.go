@@ -6,7 +6,6 @@ import (
 	"io"
 	"math/big"
 	"math/rand"
-	"time"
 )
 
 var (
@@ -34,6 +33,8 @@ type Rand interface {
 	Int63n(n int64) int64
 	Float32() float32
 	Float64() float64
+	NormFloat64() float64
+	ExpFloat64() float64
 	Read(p []byte) (n int, err error)
 }
 
@@ -43,13 +44,22 @@ type StringRand interface {
 	String(n int, alphabet []rune) string
 }
 
-// New creates a new RNG around a given source.
+// New creates a new RNG around a given source. The returned Rand is not safe
+// for concurrent use; use NewPooled or Default when sharing a Rand between
+// goroutines.
 func New(src rand.Source) Rand {
 	return &rng{
 		Rand: rand.New(src),
 	}
 }
 
+// NewSeeded creates a new RNG with a fixed seed, which produces the same
+// sequence of values on every run. Useful for deterministic tests and
+// reproducible simulations.
+func NewSeeded(seed int64) Rand {
+	return New(rand.NewSource(seed))
+}
+
 type rng struct {
 	*rand.Rand // delegates most functions to the underlying rng
 }
@@ -110,11 +120,16 @@ func SecureString(n int, alphabet []rune) string {
 // String returns a string of length n, composed of random
 // characters from the provided alphabet. If the input
 // slice is nil, returns a random mixed case alphanumeric
-// string.
+// string. Safe for concurrent use.
 func String(n int, alphabet []rune) string {
 	return defaultRNG.String(n, alphabet)
 }
 
+// Default returns the package's default Rand, which is safe for concurrent use.
+func Default() Rand {
+	return defaultRNG
+}
+
 var (
-	defaultRNG = New(rand.NewSource(time.Now().UnixMilli()))
+	defaultRNG = NewPooled()
 )
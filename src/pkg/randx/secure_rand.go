@@ -0,0 +1,163 @@
+package randx
+
+import (
+	cryptrand "crypto/rand"
+	"encoding/binary"
+	"io"
+	"math"
+	"sync"
+)
+
+// A SecureRand is a Rand backed by a cryptographically secure source, such as
+// crypto/rand. Since the methods of Rand cannot return errors, a SecureRand
+// records the first error from the underlying source and returns zero values
+// from then on; callers should check Err after generating security-sensitive
+// values. Read returns errors directly. A SecureRand is safe for concurrent
+// use if the underlying source is.
+type SecureRand struct {
+	r   io.Reader
+	mut sync.Mutex
+	err error
+}
+
+// NewSecureRand returns a SecureRand that reads from r. If r is nil,
+// crypto/rand.Reader is used.
+func NewSecureRand(r io.Reader) *SecureRand {
+	if r == nil {
+		r = cryptrand.Reader
+	}
+
+	return &SecureRand{r: r}
+}
+
+// Err returns the first error encountered reading from the underlying source.
+func (r *SecureRand) Err() error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return r.err
+}
+
+// Read fills p with random bytes.
+func (r *SecureRand) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(r.r, p)
+	if err != nil {
+		r.mut.Lock()
+		if r.err == nil {
+			r.err = err
+		}
+		r.mut.Unlock()
+	}
+
+	return n, err
+}
+
+func (r *SecureRand) uint64() uint64 {
+	if r.Err() != nil {
+		return 0
+	}
+
+	var b [8]byte
+	if _, err := r.Read(b[:]); err != nil {
+		return 0
+	}
+
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Int63 returns a non-negative random 63-bit integer as an int64.
+func (r *SecureRand) Int63() int64 {
+	return int64(r.uint64() >> 1)
+}
+
+// Int31 returns a non-negative random 31-bit integer as an int32.
+func (r *SecureRand) Int31() int32 {
+	return int32(r.Int63() >> 32)
+}
+
+// Int returns a non-negative random int.
+func (r *SecureRand) Int() int {
+	return int(uint(r.Int63()))
+}
+
+// Int63n returns a non-negative random number in the half-open interval [0,n).
+// Panics if n <= 0.
+func (r *SecureRand) Int63n(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int63n")
+	}
+
+	if n&(n-1) == 0 { // n is a power of two, so can mask
+		return r.Int63() & (n - 1)
+	}
+
+	// Reject values from the incomplete final block to avoid modulo bias
+	maxVal := int64((1 << 63) - 1 - (1<<63)%uint64(n))
+	v := r.Int63()
+	for v > maxVal {
+		v = r.Int63()
+	}
+
+	return v % n
+}
+
+// Int31n returns a non-negative random number in the half-open interval [0,n).
+// Panics if n <= 0.
+func (r *SecureRand) Int31n(n int32) int32 {
+	if n <= 0 {
+		panic("invalid argument to Int31n")
+	}
+
+	return int32(r.Int63n(int64(n)))
+}
+
+// Intn returns a non-negative random number in the half-open interval [0,n).
+// Panics if n <= 0.
+func (r *SecureRand) Intn(n int) int {
+	if n <= 0 {
+		panic("invalid argument to Intn")
+	}
+
+	return int(r.Int63n(int64(n)))
+}
+
+// Float64 returns a random number in the half-open interval [0.0,1.0).
+func (r *SecureRand) Float64() float64 {
+	return float64(r.Int63n(1<<53)) / (1 << 53)
+}
+
+// Float32 returns a random number in the half-open interval [0.0,1.0).
+func (r *SecureRand) Float32() float32 {
+	return float32(r.Int63n(1<<24)) / (1 << 24)
+}
+
+// NormFloat64 returns a normally distributed number with a mean of 0
+// and a standard deviation of 1.
+func (r *SecureRand) NormFloat64() float64 {
+	// Box-Muller transform; 1-Float64 is in (0, 1] so the log is finite
+	u1, u2 := 1-r.Float64(), r.Float64()
+	return math.Sqrt(-2*math.Log(u1)) * math.Cos(2*math.Pi*u2)
+}
+
+// ExpFloat64 returns an exponentially distributed number with a rate
+// parameter (lambda) of 1.
+func (r *SecureRand) ExpFloat64() float64 {
+	return -math.Log(1 - r.Float64())
+}
+
+// String returns a string of length n, composed of random characters from
+// the provided alphabet. If the alphabet is nil, returns a random mixed case
+// alphanumeric string.
+func (r *SecureRand) String(n int, alphabet []rune) string {
+	if alphabet == nil {
+		alphabet = alphanum
+	}
+
+	out := make([]rune, n)
+	for i := range out {
+		out[i] = alphabet[r.Intn(len(alphabet))]
+	}
+
+	return string(out)
+}
+
+var _ Rand = &SecureRand{}
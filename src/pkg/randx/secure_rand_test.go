@@ -0,0 +1,63 @@
+package randx
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecureRand(t *testing.T) {
+	r := NewSecureRand(rand.New(rand.NewSource(56746))) // deterministic source
+	assert.Equal(t, 7426829899025976640, r.Int())
+	assert.Equal(t, 2820847, r.Intn(3933409))
+	assert.Equal(t, int32(477404777), r.Int31())
+	assert.Equal(t, int32(1053), r.Int31n(3534))
+	assert.Equal(t, int64(4138338118565384487), r.Int63())
+	assert.Equal(t, int64(6109498), r.Int63n(239034904))
+	assert.Equal(t, float32(0.68963665), r.Float32())
+	assert.Equal(t, 0.7351838351209375, r.Float64())
+	assert.Equal(t, "vyzzavtgwi", r.String(10, []rune("abcdefghijklmnopqrstuvwxyz")))
+	assert.NoError(t, r.Err())
+}
+
+func TestSecureRand_Ranges(t *testing.T) {
+	r := NewSecureRand(nil)
+	for i := 0; i < 1000; i++ {
+		assert.GreaterOrEqual(t, r.Int(), 0)
+		assert.Less(t, r.Intn(7), 7)
+		assert.Less(t, r.Int63n(1<<40), int64(1<<40))
+		assert.Less(t, r.Int31n(1000), int32(1000))
+		assert.Less(t, r.Float64(), 1.0)
+		assert.Less(t, r.Float32(), float32(1.0))
+	}
+
+	assert.Len(t, r.String(12, nil), 12)
+	assert.Panics(t, func() { r.Intn(0) })
+	assert.NoError(t, r.Err())
+}
+
+func TestSecureRand_Error(t *testing.T) {
+	readErr := errors.New("entropy exhausted")
+	r := NewSecureRand(failingReader{err: readErr})
+
+	n, err := r.Read(make([]byte, 4))
+	assert.Equal(t, 0, n)
+	require.ErrorIs(t, err, readErr)
+
+	// Errors are surfaced through Err rather than panicking
+	assert.Equal(t, 0, r.Intn(10))
+	assert.Equal(t, 0.0, r.Float64())
+	assert.Equal(t, "aaa", r.String(3, []rune("abc")))
+	require.ErrorIs(t, r.Err(), readErr)
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r failingReader) Read(_ []byte) (int, error) {
+	return 0, r.err
+}
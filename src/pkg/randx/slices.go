@@ -0,0 +1,47 @@
+package randx
+
+import (
+	"slices"
+)
+
+// Shuffle randomly shuffles the elements of s in place.
+func Shuffle[T any](r Rand, s []T) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := r.Intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Perm returns a random permutation of the integers [0, n).
+func Perm(r Rand, n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		p[i] = i
+	}
+
+	Shuffle(r, p)
+	return p
+}
+
+// Sample returns k elements chosen at random from s, without replacement,
+// in random order. If k is larger than the length of s, all elements of s are
+// returned in random order. The input slice is not modified.
+func Sample[T any](r Rand, s []T, k int) []T {
+	k = max(0, min(k, len(s)))
+	s = slices.Clone(s)
+	for i := 0; i < k; i++ {
+		j := i + r.Intn(len(s)-i)
+		s[i], s[j] = s[j], s[i]
+	}
+
+	return slices.Clip(s[:k])
+}
+
+// Pick returns an element of s chosen at random. Panics if s is empty.
+func Pick[T any](r Rand, s []T) T {
+	if len(s) == 0 {
+		panic("randx: cannot pick from an empty slice")
+	}
+
+	return s[r.Intn(len(s))]
+}
@@ -0,0 +1,56 @@
+package randx
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShuffle(t *testing.T) {
+	r := New(rand.NewSource(56746)) // fixed seed
+	s := []string{"a", "b", "c", "d", "e"}
+	Shuffle(r, s)
+	assert.Equal(t, []string{"b", "c", "d", "e", "a"}, s)
+
+	var empty []string
+	Shuffle(r, empty)
+	assert.Empty(t, empty)
+}
+
+func TestPerm(t *testing.T) {
+	r := New(rand.NewSource(56746)) // fixed seed
+	assert.Equal(t, []int{1, 0, 4, 5, 2, 3}, Perm(r, 6))
+	assert.Equal(t, []int{}, Perm(r, 0))
+}
+
+func TestSample(t *testing.T) {
+	s := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	for _, tt := range []struct {
+		name     string
+		k        int
+		expected []int
+	}{
+		{"some", 3, []int{4, 8, 6}},
+		{"all", 8, []int{4, 8, 6, 7, 1, 2, 3, 5}},
+		{"more than available", 20, []int{4, 8, 6, 7, 1, 2, 3, 5}},
+		{"none", 0, []int{}},
+		{"negative", -1, []int{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(rand.NewSource(56746)) // fixed seed
+			assert.Equal(t, tt.expected, Sample(r, s, tt.k))
+		})
+	}
+
+	// The input is not modified
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, s)
+}
+
+func TestPick(t *testing.T) {
+	r := New(rand.NewSource(56746)) // fixed seed
+	s := []string{"a", "b", "c", "d", "e"}
+	assert.Equal(t, "a", Pick(r, s))
+	assert.Equal(t, "c", Pick(r, s))
+	assert.Panics(t, func() { Pick(r, []string{}) })
+}
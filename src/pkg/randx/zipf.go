@@ -0,0 +1,71 @@
+package randx
+
+import (
+	"math"
+)
+
+// A Zipf generates Zipf distributed values, where the probability of a
+// value k is proportional to (v + k) ** (-s). Useful for simulating skewed
+// access patterns, such as a small set of hot keys receiving most requests.
+type Zipf struct {
+	r            Rand
+	imax         float64
+	v            float64
+	q            float64
+	s            float64
+	oneminusQ    float64
+	oneminusQinv float64
+	hxm          float64
+	hx0minusHxm  float64
+}
+
+// NewZipf returns a Zipf generating values in [0, imax] using r as the
+// source of randomness. Requires s > 1 and v >= 1; returns nil otherwise.
+// The algorithm is rejection-inversion sampling, as used by math/rand.Zipf.
+func NewZipf(r Rand, s, v float64, imax uint64) *Zipf {
+	if s <= 1.0 || v < 1 {
+		return nil
+	}
+
+	z := &Zipf{
+		r:    r,
+		imax: float64(imax),
+		v:    v,
+		q:    s,
+	}
+
+	z.oneminusQ = 1.0 - z.q
+	z.oneminusQinv = 1.0 / z.oneminusQ
+	z.hxm = z.h(z.imax + 0.5)
+	z.hx0minusHxm = z.h(0.5) - math.Exp(math.Log(z.v)*(-z.q)) - z.hxm
+	z.s = 1 - z.hinv(z.h(1.5)-math.Exp(-z.q*math.Log(z.v+1.0)))
+	return z
+}
+
+func (z *Zipf) h(x float64) float64 {
+	return math.Exp(z.oneminusQ*math.Log(z.v+x)) * z.oneminusQinv
+}
+
+func (z *Zipf) hinv(x float64) float64 {
+	return math.Exp(z.oneminusQinv*math.Log(z.oneminusQ*x)) - z.v
+}
+
+// Uint64 returns a value drawn from the Zipf distribution.
+func (z *Zipf) Uint64() uint64 {
+	var k float64
+	for {
+		r := z.r.Float64()
+		ur := z.hxm + r*z.hx0minusHxm
+		x := z.hinv(ur)
+		k = math.Floor(x + 0.5)
+		if k-x <= z.s {
+			break
+		}
+
+		if ur >= z.h(k+0.5)-math.Exp(-math.Log(k+z.v)*z.q) {
+			break
+		}
+	}
+
+	return uint64(k)
+}
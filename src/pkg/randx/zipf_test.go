@@ -0,0 +1,71 @@
+package randx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZipf(t *testing.T) {
+	assert.Nil(t, NewZipf(NewSeeded(1), 1, 1, 10))
+	assert.Nil(t, NewZipf(NewSeeded(1), 2, 0.5, 10))
+
+	z := NewZipf(NewSeeded(56746), 1.5, 1, 99)
+	require.NotNil(t, z)
+
+	counts := make([]int, 100)
+	for i := 0; i < 10000; i++ {
+		v := z.Uint64()
+		require.LessOrEqual(t, v, uint64(99))
+		counts[v]++
+	}
+
+	// Lower values are much more likely than higher values
+	assert.Greater(t, counts[0], counts[1])
+	assert.Greater(t, counts[1], counts[5])
+	assert.Greater(t, counts[0], 10*counts[50])
+}
+
+func TestDistributions(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		r    Rand
+	}{
+		{"seeded", NewSeeded(56746)},
+		{"pooled", NewPooled()},
+		{"secure", NewSecureRand(nil)},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			const n = 20000
+			var normSum, normSqSum, expSum float64
+			for i := 0; i < n; i++ {
+				v := tt.r.NormFloat64()
+				normSum += v
+				normSqSum += v * v
+
+				e := tt.r.ExpFloat64()
+				require.GreaterOrEqual(t, e, 0.0)
+				expSum += e
+			}
+
+			mean := normSum / n
+			assert.InDelta(t, 0, mean, 0.05)
+			assert.InDelta(t, 1, math.Sqrt(normSqSum/n-mean*mean), 0.05)
+			assert.InDelta(t, 1, expSum/n, 0.05)
+		})
+	}
+}
+
+func TestNewSeeded(t *testing.T) {
+	r1, r2 := NewSeeded(56746), NewSeeded(56746)
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, r1.NormFloat64(), r2.NormFloat64())
+		assert.Equal(t, r1.ExpFloat64(), r2.ExpFloat64())
+	}
+
+	r := NewSeeded(56746)
+	assert.Equal(t, 0.7607573481929203, r.NormFloat64())
+	assert.Equal(t, 2.036270486051976, r.ExpFloat64())
+}
@@ -0,0 +1,130 @@
+package timex
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// A Calendar determines which dates are business days.
+type Calendar interface {
+	// IsWeekend returns true if the date falls on a weekend.
+	IsWeekend(d Date) bool
+
+	// IsHoliday returns true if the date is a holiday.
+	IsHoliday(d Date) bool
+}
+
+// A HolidayCalendar is a Calendar with a fixed set of weekend days
+// and a list of holidays.
+type HolidayCalendar struct {
+	weekend  [7]bool
+	holidays map[Date]struct{}
+}
+
+// NewHolidayCalendar returns a Calendar with a Saturday and Sunday
+// weekend and the given holidays.
+func NewHolidayCalendar(holidays ...Date) *HolidayCalendar {
+	cal := &HolidayCalendar{
+		holidays: make(map[Date]struct{}, len(holidays)),
+	}
+
+	cal.SetWeekend(time.Saturday, time.Sunday)
+	cal.AddHolidays(holidays...)
+	return cal
+}
+
+// SetWeekend sets the days of the week that are the weekend. Panics if every
+// day of the week is a weekend day, since the calendar would then have no
+// business days to count.
+func (cal *HolidayCalendar) SetWeekend(days ...time.Weekday) {
+	var weekend [7]bool
+	for _, day := range days {
+		weekend[day] = true
+	}
+
+	if !slices.Contains(weekend[:], false) {
+		panic("timex: calendar weekend cannot include every day of the week")
+	}
+
+	cal.weekend = weekend
+}
+
+// AddHolidays adds holidays to the calendar.
+func (cal *HolidayCalendar) AddHolidays(holidays ...Date) {
+	for _, d := range holidays {
+		cal.holidays[d] = struct{}{}
+	}
+}
+
+// IsWeekend returns true if the date falls on a weekend.
+func (cal *HolidayCalendar) IsWeekend(d Date) bool {
+	return cal.weekend[d.Weekday()]
+}
+
+// IsHoliday returns true if the date is a holiday.
+func (cal *HolidayCalendar) IsHoliday(d Date) bool {
+	_, ok := cal.holidays[d]
+	return ok
+}
+
+// WeekendCalendar is a Calendar with a Saturday and Sunday weekend and no holidays.
+var WeekendCalendar Calendar = NewHolidayCalendar()
+
+// IsBusinessDay returns true if the date is neither a weekend nor a holiday.
+func IsBusinessDay(d Date, cal Calendar) bool {
+	return !cal.IsWeekend(d) && !cal.IsHoliday(d)
+}
+
+// AddBusinessDays returns the date that is numDays business days from the
+// given date, skipping weekends and holidays. numDays can be negative. If
+// numDays is zero, the date is returned as is, even if it is not a business day.
+// Panics if the calendar has no business days for more than a year at a time,
+// rather than searching forever.
+func AddBusinessDays(d Date, numDays int, cal Calendar) Date {
+	step := 1
+	if numDays < 0 {
+		step, numDays = -1, -numDays
+	}
+
+	for skipped := 0; numDays > 0; {
+		d = d.AddDays(step)
+		if IsBusinessDay(d, cal) {
+			numDays, skipped = numDays-1, 0
+			continue
+		}
+
+		if skipped++; skipped > maxNonBusinessDays {
+			panic(fmt.Sprintf("timex: calendar has no business days within %d days of %s", maxNonBusinessDays, d))
+		}
+	}
+
+	return d
+}
+
+// maxNonBusinessDays is the longest run of weekends and holidays allowed
+// before a calendar is considered to have no business days.
+const maxNonBusinessDays = 366
+
+// BusinessDaysBetween returns the number of business days after from, up to
+// and including to, which is negative if to is before from. For a business
+// day to, AddBusinessDays(from, BusinessDaysBetween(from, to, cal), cal) is to.
+func BusinessDaysBetween(from, to Date, cal Calendar) int {
+	sign := 1
+	if to.Before(from) {
+		sign, from, to = -1, to.AddDays(-1), from.AddDays(-1)
+	}
+
+	var n int
+	for d := from.NextDay(); !d.After(to); d = d.NextDay() {
+		if IsBusinessDay(d, cal) {
+			n++
+		}
+	}
+
+	return sign * n
+}
+
+var (
+	_ Calendar = &HolidayCalendar{}
+)
@@ -0,0 +1,108 @@
+package timex
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBusinessDay(t *testing.T) {
+	cal := NewHolidayCalendar(MustParseDate("2024-07-04"))
+	for _, tt := range []struct {
+		date string
+		want bool
+	}{
+		{"2024-07-03", true},  // Wednesday
+		{"2024-07-04", false}, // holiday
+		{"2024-07-05", true},  // Friday
+		{"2024-07-06", false}, // Saturday
+		{"2024-07-07", false}, // Sunday
+		{"2024-07-08", true},  // Monday
+	} {
+		assert.Equal(t, tt.want, IsBusinessDay(MustParseDate(tt.date), cal), tt.date)
+	}
+
+	assert.True(t, IsBusinessDay(MustParseDate("2024-07-04"), WeekendCalendar))
+
+	cal.SetWeekend(time.Friday, time.Saturday)
+	assert.False(t, IsBusinessDay(MustParseDate("2024-07-05"), cal))
+	assert.True(t, IsBusinessDay(MustParseDate("2024-07-07"), cal))
+}
+
+func TestAddBusinessDays(t *testing.T) {
+	cal := NewHolidayCalendar(MustParseDate("2024-07-04"), MustParseDate("2024-12-25"))
+	for _, tt := range []struct {
+		start string
+		days  int
+		want  string
+	}{
+		{"2024-07-01", 0, "2024-07-01"},
+		{"2024-07-06", 0, "2024-07-06"}, // not a business day, returned as is
+		{"2024-07-01", 1, "2024-07-02"},
+		{"2024-07-03", 1, "2024-07-05"},  // skip holiday
+		{"2024-07-05", 1, "2024-07-08"},  // skip weekend
+		{"2024-07-06", 1, "2024-07-08"},  // start on weekend
+		{"2024-07-01", 10, "2024-07-16"}, // skip holiday and weekends
+		{"2024-12-20", 5, "2024-12-30"},
+		{"2024-07-08", -1, "2024-07-05"},
+		{"2024-07-05", -1, "2024-07-03"},
+		{"2024-07-16", -10, "2024-07-01"},
+	} {
+		t.Run(fmt.Sprintf("%s+%d", tt.start, tt.days), func(t *testing.T) {
+			start, want := MustParseDate(tt.start), MustParseDate(tt.want)
+			assert.Equal(t, want, AddBusinessDays(start, tt.days, cal))
+
+			if IsBusinessDay(start, cal) {
+				assert.Equal(t, tt.days, BusinessDaysBetween(start, want, cal))
+			}
+		})
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	cal := NewHolidayCalendar(MustParseDate("2024-07-04"))
+	for _, tt := range []struct {
+		from string
+		to   string
+		want int
+	}{
+		{"2024-07-01", "2024-07-01", 0},
+		{"2024-07-01", "2024-07-02", 1},
+		{"2024-07-01", "2024-07-07", 3},  // skip holiday and weekend
+		{"2024-07-06", "2024-07-07", 0},  // weekend only
+		{"2024-07-05", "2024-07-15", 6},  // two weekends
+		{"2024-07-07", "2024-07-01", -4}, // backwards, from is not a business day
+		{"2024-07-15", "2024-07-05", -6},
+	} {
+		t.Run(fmt.Sprintf("%s to %s", tt.from, tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.want, BusinessDaysBetween(MustParseDate(tt.from), MustParseDate(tt.to), cal))
+		})
+	}
+}
+
+type closedCalendar struct{}
+
+func (closedCalendar) IsWeekend(Date) bool { return false }
+func (closedCalendar) IsHoliday(Date) bool { return true }
+
+func TestNoBusinessDays(t *testing.T) {
+	assert.PanicsWithValue(t, "timex: calendar weekend cannot include every day of the week", func() {
+		NewHolidayCalendar().SetWeekend(
+			time.Sunday, time.Monday, time.Tuesday, time.Wednesday,
+			time.Thursday, time.Friday, time.Saturday)
+	})
+
+	start := MustParseDate("2024-07-01")
+	assert.PanicsWithValue(t, "timex: calendar has no business days within 366 days of 2025-07-03", func() {
+		AddBusinessDays(start, 1, closedCalendar{})
+	})
+	assert.PanicsWithValue(t, "timex: calendar has no business days within 366 days of 2023-06-30", func() {
+		AddBusinessDays(start, -1, closedCalendar{})
+	})
+
+	// Counting is bounded by the dates, so long stretches without business days are fine
+	assert.Equal(t, 0, BusinessDaysBetween(start, start.AddDays(1000), closedCalendar{}))
+	assert.Equal(t, 0, BusinessDaysBetween(start, start.AddDays(-1000), closedCalendar{}))
+}
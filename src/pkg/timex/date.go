@@ -3,7 +3,10 @@ package timex
 import (
 	"encoding"
 	"fmt"
+	"slices"
 	"time"
+
+	"gopkg.in/yaml.v3"
 )
 
 // A Date is a month, day, year.
@@ -27,6 +30,30 @@ func ParseDate(s string) (Date, error) {
 	}, nil
 }
 
+// DateFromTime returns the date of a time, in the time's location.
+func DateFromTime(t time.Time) Date {
+	return Date{
+		Day:   t.Day(),
+		Month: t.Month(),
+		Year:  t.Year(),
+	}
+}
+
+// DateFromTimeIn returns the date of a time in the given location.
+func DateFromTimeIn(t time.Time, loc *time.Location) Date {
+	return DateFromTime(t.In(loc))
+}
+
+// Today returns the current date in the given location.
+func Today(clock Clock, loc *time.Location) Date {
+	return DateFromTimeIn(clock.Now(), loc)
+}
+
+// TodayUTC returns the current date in UTC.
+func TodayUTC(clock Clock) Date {
+	return Today(clock, time.UTC)
+}
+
 // MustParseDate parses a date, panicking if the date can't be parsed.
 // Useful for tests.
 func MustParseDate(s string) Date {
@@ -50,6 +77,27 @@ func (d *Date) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalText marshals the date as a text value.
+// Implements the TextMarshaler interface.
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalYAML unmarshals the date from a YAML string.
+func (d *Date) UnmarshalYAML(n *yaml.Node) error {
+	var s string
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return d.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals the date as a YAML string.
+func (d Date) MarshalYAML() (any, error) {
+	return d.String(), nil
+}
+
 // String returns a string format of the date.
 func (d Date) String() string {
 	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
@@ -65,16 +113,91 @@ func (d Date) DayEnd() time.Time {
 	return d.DayStart().Add(time.Hour * 24).Add(-time.Nanosecond)
 }
 
+// DayStartIn returns the time at the start of the day in the given location.
+func (d Date) DayStartIn(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// DayEndIn returns the time at the end of the day in the given location.
+// Days are not always 24 hours long in locations with daylight savings.
+func (d Date) DayEndIn(loc *time.Location) time.Time {
+	return d.NextDay().DayStartIn(loc).Add(-time.Nanosecond)
+}
+
 // NextDay returns the next day.
 func (d Date) NextDay() Date {
-	nextDay := d.DayStart().Add(time.Hour * 24)
+	return d.AddDays(1)
+}
+
+// AddDays returns the date numDays out. numDays can be negative.
+func (d Date) AddDays(numDays int) Date {
+	return DateFromTime(d.DayStart().AddDate(0, 0, numDays))
+}
+
+// AddMonths returns the same day numMonths out. If the day does not exist
+// in the resulting month, the last day of that month is used instead, so
+// 2024-01-31 plus one month is 2024-02-29.
+func (d Date) AddMonths(numMonths int) Date {
+	my := d.MonthYear().AddMonths(numMonths)
 	return Date{
-		Day:   nextDay.Day(),
-		Month: nextDay.Month(),
-		Year:  nextDay.Year(),
+		Day:   min(d.Day, my.Days()),
+		Month: my.Month,
+		Year:  my.Year,
 	}
 }
 
+// AddYears returns the same day numYears out. February 29th maps to
+// February 28th in years that are not leap years.
+func (d Date) AddYears(numYears int) Date {
+	return d.AddMonths(numYears * 12)
+}
+
+// Sub returns the number of days from another date to this date, which
+// is negative if the other date is after this date.
+func (d Date) Sub(other Date) int {
+	return int((d.DayStart().Unix() - other.DayStart().Unix()) / secondsPerDay)
+}
+
+// DaysBetween returns the number of days between two dates.
+func DaysBetween(from, to Date) int {
+	daysBetween := to.Sub(from)
+	if daysBetween < 0 {
+		return -daysBetween
+	}
+
+	return daysBetween
+}
+
+// Weekday returns the day of the week of the date.
+func (d Date) Weekday() time.Weekday {
+	return d.DayStart().Weekday()
+}
+
+// DayOfYear returns the day of the year, from 1 to 365 (or 366 in leap years).
+func (d Date) DayOfYear() int {
+	return d.DayStart().YearDay()
+}
+
+// WeekOfMonth returns the week of the month the date falls in, from 1 to 5,
+// where the first week is the 1st through the 7th of the month. The Nth
+// occurrence of a weekday always falls in week N.
+func (d Date) WeekOfMonth() int {
+	return (d.Day-1)/7 + 1
+}
+
+// MonthYear returns the month and year of the date.
+func (d Date) MonthYear() MonthYear {
+	return MonthYear{
+		Month: d.Month,
+		Year:  d.Year,
+	}
+}
+
+// Quarter returns the quarter containing the date.
+func (d Date) Quarter() Quarter {
+	return d.MonthYear().Quarter()
+}
+
 // IsZero returns true if the date value is not set.
 func (d Date) IsZero() bool {
 	return d.Day == 0 && d.Month == 0 && d.Year == 0
@@ -129,6 +252,59 @@ func (d Date) Less(other Date) bool {
 	return d.CompareTo(other) < 0
 }
 
+const secondsPerDay = 24 * 60 * 60
+
+// Compare compares this date to another, following the conventions
+// of cmp.Compare, so that Dates can be sorted with slices.SortFunc.
+func (d Date) Compare(other Date) int {
+	return d.CompareTo(other)
+}
+
+// Clamp returns the date bounded to the range [lo, hi].
+func (d Date) Clamp(lo, hi Date) Date {
+	if d.Before(lo) {
+		return lo
+	}
+
+	if d.After(hi) {
+		return hi
+	}
+
+	return d
+}
+
+// MinDate returns the earliest of the given Dates.
+func MinDate(first Date, rest ...Date) Date {
+	earliest := first
+	for _, d := range rest {
+		if d.Before(earliest) {
+			earliest = d
+		}
+	}
+
+	return earliest
+}
+
+// MaxDate returns the latest of the given Dates.
+func MaxDate(first Date, rest ...Date) Date {
+	latest := first
+	for _, d := range rest {
+		if d.After(latest) {
+			latest = d
+		}
+	}
+
+	return latest
+}
+
+// SortDates sorts Dates in ascending order.
+func SortDates(values []Date) {
+	slices.SortFunc(values, Date.Compare)
+}
+
 var (
 	_ encoding.TextUnmarshaler = &Date{}
+	_ encoding.TextMarshaler   = Date{}
+	_ yaml.Unmarshaler         = &Date{}
+	_ yaml.Marshaler           = Date{}
 )
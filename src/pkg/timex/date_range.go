@@ -0,0 +1,109 @@
+package timex
+
+import (
+	"fmt"
+	"iter"
+)
+
+// A DateRange is a range of dates, inclusive of both the From and To dates.
+// A range where To is before From is empty.
+type DateRange struct {
+	From Date
+	To   Date
+}
+
+// NewDateRange returns the range between two dates, inclusive.
+func NewDateRange(from, to Date) DateRange {
+	return DateRange{From: from, To: to}
+}
+
+// String returns a string format of the range, as an ISO 8601 interval.
+func (r DateRange) String() string {
+	return fmt.Sprintf("%s/%s", r.From, r.To)
+}
+
+// IsEmpty returns true if the range contains no dates.
+func (r DateRange) IsEmpty() bool {
+	return r.To.Before(r.From)
+}
+
+// NumDays returns the number of days in the range.
+func (r DateRange) NumDays() int {
+	if r.IsEmpty() {
+		return 0
+	}
+
+	return r.To.Sub(r.From) + 1
+}
+
+// Contains returns true if the date falls within the range.
+func (r DateRange) Contains(d Date) bool {
+	return !d.Before(r.From) && !d.After(r.To)
+}
+
+// Overlaps returns true if the two ranges have at least one date in common.
+func (r DateRange) Overlaps(other DateRange) bool {
+	return !r.Intersect(other).IsEmpty()
+}
+
+// Intersect returns the dates common to both ranges, which is empty if
+// the ranges do not overlap.
+func (r DateRange) Intersect(other DateRange) DateRange {
+	from, to := r.From, r.To
+	if other.From.After(from) {
+		from = other.From
+	}
+
+	if other.To.Before(to) {
+		to = other.To
+	}
+
+	return DateRange{From: from, To: to}
+}
+
+// Union returns the range covering both ranges. Returns false if the ranges
+// neither overlap nor are adjacent, since the union would not be contiguous.
+func (r DateRange) Union(other DateRange) (DateRange, bool) {
+	if r.IsEmpty() {
+		return other, true
+	}
+
+	if other.IsEmpty() {
+		return r, true
+	}
+
+	if r.To.NextDay().Before(other.From) || other.To.NextDay().Before(r.From) {
+		return DateRange{}, false
+	}
+
+	from, to := r.From, r.To
+	if other.From.Before(from) {
+		from = other.From
+	}
+
+	if other.To.After(to) {
+		to = other.To
+	}
+
+	return DateRange{From: from, To: to}, true
+}
+
+// Days returns an iterator over the dates in the range, in order.
+func (r DateRange) Days() iter.Seq[Date] {
+	return func(yield func(Date) bool) {
+		for d := r.From; !d.After(r.To); d = d.NextDay() {
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// Months returns an iterator over the months that the range touches, in order.
+func (r DateRange) Months() iter.Seq[MonthYear] {
+	if r.IsEmpty() {
+		return func(func(MonthYear) bool) {}
+	}
+
+	return MonthsRange(r.From.MonthYear(), r.To.MonthYear())
+}
@@ -0,0 +1,119 @@
+package timex
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dateRange(from, to string) DateRange {
+	return NewDateRange(MustParseDate(from), MustParseDate(to))
+}
+
+func TestDateRange(t *testing.T) {
+	r := dateRange("2024-02-27", "2024-03-02")
+	assert.Equal(t, "2024-02-27/2024-03-02", r.String())
+	assert.False(t, r.IsEmpty())
+	assert.Equal(t, 5, r.NumDays())
+	assert.Equal(t, 1, dateRange("2024-02-27", "2024-02-27").NumDays())
+	assert.Equal(t, 0, dateRange("2024-02-27", "2024-02-26").NumDays())
+	assert.True(t, dateRange("2024-02-27", "2024-02-26").IsEmpty())
+
+	for _, tt := range []struct {
+		date string
+		want bool
+	}{
+		{"2024-02-26", false},
+		{"2024-02-27", true},
+		{"2024-02-29", true},
+		{"2024-03-02", true},
+		{"2024-03-03", false},
+	} {
+		assert.Equal(t, tt.want, r.Contains(MustParseDate(tt.date)), tt.date)
+	}
+}
+
+func TestDateRange_Intersect(t *testing.T) {
+	base := dateRange("2024-01-10", "2024-01-20")
+	for _, tt := range []struct {
+		name     string
+		other    DateRange
+		overlaps bool
+		want     DateRange
+	}{
+		{"contained", dateRange("2024-01-12", "2024-01-15"), true, dateRange("2024-01-12", "2024-01-15")},
+		{"containing", dateRange("2024-01-01", "2024-01-31"), true, base},
+		{"start", dateRange("2024-01-01", "2024-01-10"), true, dateRange("2024-01-10", "2024-01-10")},
+		{"end", dateRange("2024-01-15", "2024-01-31"), true, dateRange("2024-01-15", "2024-01-20")},
+		{"before", dateRange("2024-01-01", "2024-01-09"), false, dateRange("2024-01-10", "2024-01-09")},
+		{"after", dateRange("2024-01-21", "2024-01-31"), false, dateRange("2024-01-21", "2024-01-20")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.overlaps, base.Overlaps(tt.other))
+			assert.Equal(t, tt.overlaps, tt.other.Overlaps(base))
+			assert.Equal(t, tt.want, base.Intersect(tt.other))
+		})
+	}
+}
+
+func TestDateRange_Union(t *testing.T) {
+	base := dateRange("2024-01-10", "2024-01-20")
+	for _, tt := range []struct {
+		name  string
+		other DateRange
+		ok    bool
+		want  DateRange
+	}{
+		{"contained", dateRange("2024-01-12", "2024-01-15"), true, base},
+		{"overlapping", dateRange("2024-01-15", "2024-01-31"), true, dateRange("2024-01-10", "2024-01-31")},
+		{"adjacent before", dateRange("2024-01-01", "2024-01-09"), true, dateRange("2024-01-01", "2024-01-20")},
+		{"adjacent after", dateRange("2024-01-21", "2024-01-31"), true, dateRange("2024-01-10", "2024-01-31")},
+		{"disjoint", dateRange("2024-01-22", "2024-01-31"), false, DateRange{}},
+		{"empty", dateRange("2024-02-22", "2024-01-31"), true, base},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			union, ok := base.Union(tt.other)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, union)
+
+			union, ok = tt.other.Union(base)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, union)
+		})
+	}
+}
+
+func TestDateRange_Days(t *testing.T) {
+	assert.Equal(t, []Date{
+		MustParseDate("2024-02-28"),
+		MustParseDate("2024-02-29"),
+		MustParseDate("2024-03-01"),
+	}, slices.Collect(dateRange("2024-02-28", "2024-03-01").Days()))
+
+	assert.Empty(t, slices.Collect(dateRange("2024-02-28", "2024-02-27").Days()))
+
+	var first []Date
+	for d := range dateRange("2024-02-28", "2024-03-10").Days() {
+		if len(first) == 2 {
+			break
+		}
+		first = append(first, d)
+	}
+	assert.Len(t, first, 2)
+}
+
+func TestDateRange_Months(t *testing.T) {
+	assert.Equal(t, []MonthYear{
+		MustParseMonthYear("2023-11"),
+		MustParseMonthYear("2023-12"),
+		MustParseMonthYear("2024-01"),
+		MustParseMonthYear("2024-02"),
+	}, slices.Collect(dateRange("2023-11-30", "2024-02-01").Months()))
+
+	assert.Equal(t, []MonthYear{
+		MustParseMonthYear("2024-02"),
+	}, slices.Collect(dateRange("2024-02-10", "2024-02-11").Months()))
+
+	assert.Empty(t, slices.Collect(dateRange("2024-02-28", "2024-02-27").Months()))
+}
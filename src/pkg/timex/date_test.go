@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"testing"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestDate(t *testing.T) {
@@ -25,6 +27,9 @@ func TestDate(t *testing.T) {
 	require.Equal(t,
 		time.Date(2023, time.October, 14, 23, 59, 59, 999999999, time.UTC),
 		d.DayEnd())
+	require.Equal(t, time.Saturday, d.Weekday())
+	require.Equal(t, MustParseMonthYear("2023-10"), d.MonthYear())
+	require.Equal(t, MustParseQuarter("2023-Q4"), d.Quarter())
 }
 
 func TestDate_CompareTo(t *testing.T) {
@@ -100,6 +105,73 @@ func TestDate_NextDay(t *testing.T) {
 	}
 }
 
+func TestDate_AddDays(t *testing.T) {
+	for _, tt := range []struct {
+		start string
+		days  int
+		want  string
+	}{
+		{"2004-01-16", 0, "2004-01-16"},
+		{"2004-01-16", 20, "2004-02-05"},
+		{"2004-02-28", 1, "2004-02-29"}, // leap year
+		{"2003-02-28", 1, "2003-03-01"}, // not a leap year
+		{"2004-12-31", 366, "2006-01-01"},
+		{"2004-03-01", -1, "2004-02-29"},
+		{"2005-01-01", -366, "2004-01-01"}, // leap year
+	} {
+		t.Run(fmt.Sprintf("%s+%d", tt.start, tt.days), func(t *testing.T) {
+			start := MustParseDate(tt.start)
+			want := MustParseDate(tt.want)
+			assert.Equal(t, want, start.AddDays(tt.days))
+			assert.Equal(t, tt.days, want.Sub(start))
+			assert.Equal(t, -tt.days, start.Sub(want))
+			assert.Equal(t, max(tt.days, -tt.days), DaysBetween(start, want))
+			assert.Equal(t, max(tt.days, -tt.days), DaysBetween(want, start))
+		})
+	}
+}
+
+func TestDate_AddMonths(t *testing.T) {
+	for _, tt := range []struct {
+		start  string
+		months int
+		want   string
+	}{
+		{"2004-01-16", 0, "2004-01-16"},
+		{"2004-01-16", 1, "2004-02-16"},
+		{"2004-01-31", 1, "2004-02-29"}, // leap year
+		{"2003-01-31", 1, "2003-02-28"}, // not a leap year
+		{"2004-03-31", 1, "2004-04-30"},
+		{"2004-11-15", 2, "2005-01-15"},
+		{"2004-11-15", 26, "2007-01-15"},
+		{"2004-01-15", -1, "2003-12-15"},
+		{"2004-03-31", -1, "2004-02-29"},
+		{"2004-01-15", -25, "2001-12-15"},
+	} {
+		t.Run(fmt.Sprintf("%s+%d", tt.start, tt.months), func(t *testing.T) {
+			assert.Equal(t, MustParseDate(tt.want), MustParseDate(tt.start).AddMonths(tt.months))
+		})
+	}
+}
+
+func TestDate_AddYears(t *testing.T) {
+	for _, tt := range []struct {
+		start string
+		years int
+		want  string
+	}{
+		{"2004-01-16", 1, "2005-01-16"},
+		{"2004-02-29", 1, "2005-02-28"},
+		{"2004-02-29", 4, "2008-02-29"},
+		{"2004-02-29", -100, "1904-02-29"},
+		{"2000-02-29", 100, "2100-02-28"},
+	} {
+		t.Run(fmt.Sprintf("%s+%d", tt.start, tt.years), func(t *testing.T) {
+			assert.Equal(t, MustParseDate(tt.want), MustParseDate(tt.start).AddYears(tt.years))
+		})
+	}
+}
+
 func TestDateJSON(t *testing.T) {
 	type Embedded struct {
 		When Date `json:"when"`
@@ -112,3 +184,137 @@ func TestDateJSON(t *testing.T) {
 		When: Date{Day: 14, Month: time.November, Year: 2023},
 	}, em)
 }
+
+func TestDateJSON_RoundTrip(t *testing.T) {
+	type Embedded struct {
+		When  Date  `json:"when"`
+		Maybe *Date `json:"maybe"`
+	}
+
+	when := Date{Day: 14, Month: time.November, Year: 2023}
+	b, err := json.Marshal(Embedded{When: when, Maybe: &when})
+	require.NoError(t, err)
+	assert.Equal(t, `{"when":"2023-11-14","maybe":"2023-11-14"}`, string(b))
+
+	var em Embedded
+	require.NoError(t, json.Unmarshal(b, &em))
+	assert.Equal(t, Embedded{When: when, Maybe: &when}, em)
+}
+
+func TestDateYAML(t *testing.T) {
+	type Embedded struct {
+		When Date `yaml:"when"`
+	}
+
+	b, err := yaml.Marshal(Embedded{When: Date{Day: 14, Month: time.November, Year: 2023}})
+	require.NoError(t, err)
+	assert.Equal(t, "when: \"2023-11-14\"\n", string(b))
+
+	var em Embedded
+	require.NoError(t, yaml.Unmarshal(b, &em))
+	assert.Equal(t, Embedded{When: Date{Day: 14, Month: time.November, Year: 2023}}, em)
+
+	err = yaml.Unmarshal([]byte("when: bad"), &em)
+	assert.Error(t, err)
+}
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func TestDate_InLocation(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	for _, tt := range []struct {
+		date  string
+		start time.Time
+		end   time.Time
+		hours float64
+	}{
+		{
+			"2024-03-09",
+			time.Date(2024, time.March, 9, 5, 0, 0, 0, time.UTC),
+			time.Date(2024, time.March, 10, 4, 59, 59, 999999999, time.UTC),
+			24,
+		},
+		{
+			"2024-03-10", // spring forward
+			time.Date(2024, time.March, 10, 5, 0, 0, 0, time.UTC),
+			time.Date(2024, time.March, 11, 3, 59, 59, 999999999, time.UTC),
+			23,
+		},
+		{
+			"2024-11-03", // fall back
+			time.Date(2024, time.November, 3, 4, 0, 0, 0, time.UTC),
+			time.Date(2024, time.November, 4, 4, 59, 59, 999999999, time.UTC),
+			25,
+		},
+	} {
+		t.Run(tt.date, func(t *testing.T) {
+			d := MustParseDate(tt.date)
+			start, end := d.DayStartIn(ny), d.DayEndIn(ny)
+			assert.True(t, tt.start.Equal(start), "start %s", start)
+			assert.True(t, tt.end.Equal(end), "end %s", end)
+			assert.Equal(t, tt.hours, end.Add(time.Nanosecond).Sub(start).Hours())
+			assert.Equal(t, d, DateFromTimeIn(start, ny))
+			assert.Equal(t, d, DateFromTimeIn(end, ny))
+		})
+	}
+}
+
+func TestToday(t *testing.T) {
+	clock := fixedClock(time.Date(2024, time.March, 10, 2, 30, 0, 0, time.UTC))
+	assert.Equal(t, MustParseDate("2024-03-10"), Today(clock, time.UTC))
+	assert.Equal(t, MustParseDate("2024-03-09"), Today(clock, time.FixedZone("EST", -5*60*60)))
+	assert.Equal(t, MustParseDate("2024-03-10"), DateFromTime(time.Time(clock)))
+	assert.Equal(t, MustParseDate("2024-03-10"), TodayUTC(clock))
+	assert.Equal(t, MustParseMonthYear("2024-03"), CurrentMonth(clock))
+	assert.Equal(t, MustParseMonthYear("2024-02"),
+		CurrentMonth(fixedClock(time.Date(2024, time.February, 29, 23, 59, 59, 0, time.UTC))))
+}
+
+func TestDate_MinMaxClampSort(t *testing.T) {
+	var (
+		d1 = MustParseDate("2023-10-14")
+		d2 = MustParseDate("2024-02-29")
+		d3 = MustParseDate("2024-03-01")
+	)
+
+	assert.Equal(t, d1, MinDate(d2, d3, d1))
+	assert.Equal(t, d3, MaxDate(d2, d3, d1))
+	assert.Equal(t, d2, MinDate(d2))
+
+	assert.Equal(t, d2, d2.Clamp(d1, d3))
+	assert.Equal(t, d2, d1.Clamp(d2, d3))
+	assert.Equal(t, d2, d3.Clamp(d1, d2))
+
+	assert.Equal(t, -1, d1.Compare(d2))
+	assert.Equal(t, 0, d2.Compare(d2))
+	assert.Equal(t, 1, d3.Compare(d2))
+
+	dates := []Date{d3, d1, d2}
+	SortDates(dates)
+	assert.Equal(t, []Date{d1, d2, d3}, dates)
+}
+
+func TestDate_DayOfYearAndWeekOfMonth(t *testing.T) {
+	for _, tt := range []struct {
+		date        string
+		dayOfYear   int
+		weekOfMonth int
+	}{
+		{"2024-01-01", 1, 1},
+		{"2024-01-07", 7, 1},
+		{"2024-01-08", 8, 2},
+		{"2024-02-29", 60, 5},
+		{"2023-03-01", 60, 1},
+		{"2024-12-31", 366, 5},
+		{"2023-12-31", 365, 5},
+		{"2023-12-28", 362, 4},
+	} {
+		d := MustParseDate(tt.date)
+		assert.Equal(t, tt.dayOfYear, d.DayOfYear(), tt.date)
+		assert.Equal(t, tt.weekOfMonth, d.WeekOfMonth(), tt.date)
+	}
+}
@@ -0,0 +1,148 @@
+package timex
+
+import (
+	"encoding"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A DateTime is a point in time that marshals to and from RFC3339, e.g.
+// 2023-10-14T09:30:00Z. When parsing, a date without a time (2023-10-14)
+// is also accepted, and is treated as the start of that day in UTC.
+type DateTime time.Time
+
+// ParseDateTime parses an RFC3339 date time, or a date without a time.
+func ParseDateTime(s string) (DateTime, error) {
+	tm, err := time.Parse(time.RFC3339, s)
+	if err == nil {
+		return DateTime(tm), nil
+	}
+
+	d, dateErr := ParseDate(s)
+	if dateErr != nil {
+		return DateTime{}, err
+	}
+
+	return DateTime(d.DayStart()), nil
+}
+
+// MustParseDateTime parses a date time, panicking if the date time
+// cannot be parsed. Useful for tests.
+func MustParseDateTime(s string) DateTime {
+	dt, err := ParseDateTime(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return dt
+}
+
+// Time returns the date time as a time.Time.
+func (dt DateTime) Time() time.Time {
+	return time.Time(dt)
+}
+
+// Date returns the date of the date time, in the date time's location.
+func (dt DateTime) Date() Date {
+	return DateFromTime(dt.Time())
+}
+
+// MonthYear returns the month and year of the date time, in the
+// date time's location.
+func (dt DateTime) MonthYear() MonthYear {
+	return dt.Date().MonthYear()
+}
+
+// UTC returns the date time in UTC.
+func (dt DateTime) UTC() DateTime {
+	return DateTime(dt.Time().UTC())
+}
+
+// In returns the date time in the given location.
+func (dt DateTime) In(loc *time.Location) DateTime {
+	return DateTime(dt.Time().In(loc))
+}
+
+// UnmarshalText unmarshalls the date time from a text value.
+// Implements the TextUnmarshaler interface.
+func (dt *DateTime) UnmarshalText(text []byte) error {
+	u, err := ParseDateTime(string(text))
+	if err != nil {
+		return err
+	}
+
+	*dt = u
+	return nil
+}
+
+// MarshalText marshals the date time as a text value.
+// Implements the TextMarshaler interface.
+func (dt DateTime) MarshalText() ([]byte, error) {
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalYAML unmarshals the date time from a YAML string.
+func (dt *DateTime) UnmarshalYAML(n *yaml.Node) error {
+	var s string
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return dt.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals the date time as a YAML string.
+func (dt DateTime) MarshalYAML() (any, error) {
+	return dt.String(), nil
+}
+
+// String returns the date time in RFC3339 format, with fractional
+// seconds if the date time has any.
+func (dt DateTime) String() string {
+	return dt.Time().Format(time.RFC3339Nano)
+}
+
+// IsZero returns true if the date time is not set.
+func (dt DateTime) IsZero() bool {
+	return dt.Time().IsZero()
+}
+
+// After returns true if this date time is after another.
+func (dt DateTime) After(other DateTime) bool {
+	return dt.CompareTo(other) > 0
+}
+
+// Before returns true if this date time is before another.
+func (dt DateTime) Before(other DateTime) bool {
+	return dt.CompareTo(other) < 0
+}
+
+// Equal returns true if this date time is the same instant as another,
+// even if they are in different locations.
+func (dt DateTime) Equal(other DateTime) bool {
+	return dt.CompareTo(other) == 0
+}
+
+// CompareTo compares this date time to another.
+func (dt DateTime) CompareTo(other DateTime) int {
+	return dt.Time().Compare(other.Time())
+}
+
+// Compare compares this date time to another, following the conventions
+// of cmp.Compare, so that DateTimes can be sorted with slices.SortFunc.
+func (dt DateTime) Compare(other DateTime) int {
+	return dt.CompareTo(other)
+}
+
+// Less returns true if this date time is earlier than another.
+func (dt DateTime) Less(other DateTime) bool {
+	return dt.CompareTo(other) < 0
+}
+
+var (
+	_ encoding.TextUnmarshaler = &DateTime{}
+	_ encoding.TextMarshaler   = DateTime{}
+	_ yaml.Unmarshaler         = &DateTime{}
+	_ yaml.Marshaler           = DateTime{}
+)
@@ -0,0 +1,107 @@
+package timex
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseDateTime(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want time.Time
+		str  string
+	}{
+		{
+			"2023-10-14T09:30:00Z",
+			time.Date(2023, time.October, 14, 9, 30, 0, 0, time.UTC),
+			"2023-10-14T09:30:00Z",
+		},
+		{
+			"2023-10-14T09:30:00.25-04:00",
+			time.Date(2023, time.October, 14, 13, 30, 0, 250000000, time.UTC),
+			"2023-10-14T09:30:00.25-04:00",
+		},
+		{
+			"2023-10-14",
+			time.Date(2023, time.October, 14, 0, 0, 0, 0, time.UTC),
+			"2023-10-14T00:00:00Z",
+		},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			dt, err := ParseDateTime(tt.s)
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(dt.Time()))
+			assert.Equal(t, tt.str, dt.String())
+			assert.Equal(t, MustParseDate("2023-10-14"), dt.Date())
+			assert.Equal(t, MustParseMonthYear("2023-10"), dt.MonthYear())
+		})
+	}
+
+	_, err := ParseDateTime("2023-10-14 09:30")
+	assert.Error(t, err)
+}
+
+func TestDateTime_InLocation(t *testing.T) {
+	dt := MustParseDateTime("2023-10-14T02:30:00Z")
+	est := time.FixedZone("EST", -5*60*60)
+	assert.Equal(t, MustParseDate("2023-10-13"), dt.In(est).Date())
+	assert.True(t, dt.Equal(dt.In(est)))
+	assert.Equal(t, dt, dt.In(est).UTC())
+}
+
+func TestDateTime_CompareTo(t *testing.T) {
+	baseline := MustParseDateTime("2023-10-14T09:30:00Z")
+	for _, tt := range []struct {
+		other string
+		want  int
+	}{
+		{"2023-10-14T09:29:59Z", 1},
+		{"2023-10-14T09:30:01Z", -1},
+		{"2023-10-14T05:30:00-04:00", 0},
+	} {
+		t.Run(fmt.Sprintf("%s vs %s", baseline, tt.other), func(t *testing.T) {
+			other := MustParseDateTime(tt.other)
+			assert.Equal(t, tt.want, baseline.CompareTo(other))
+			assert.Equal(t, tt.want, baseline.Compare(other))
+			assert.Equal(t, tt.want < 0, baseline.Less(other))
+			assert.Equal(t, tt.want < 0, baseline.Before(other))
+			assert.Equal(t, tt.want > 0, baseline.After(other))
+			assert.Equal(t, tt.want == 0, baseline.Equal(other))
+		})
+	}
+
+	assert.True(t, DateTime{}.IsZero())
+	assert.False(t, baseline.IsZero())
+}
+
+func TestDateTimeMarshalling(t *testing.T) {
+	type Embedded struct {
+		When DateTime `json:"when" yaml:"when"`
+	}
+
+	em := Embedded{When: MustParseDateTime("2023-10-14T09:30:00Z")}
+	b, err := json.Marshal(em)
+	require.NoError(t, err)
+	assert.Equal(t, `{"when":"2023-10-14T09:30:00Z"}`, string(b))
+
+	var fromJSON Embedded
+	require.NoError(t, json.Unmarshal(b, &fromJSON))
+	assert.True(t, em.When.Equal(fromJSON.When))
+
+	var fromDate Embedded
+	require.NoError(t, json.Unmarshal([]byte(`{"when":"2023-10-14"}`), &fromDate))
+	assert.Equal(t, "2023-10-14T00:00:00Z", fromDate.When.String())
+
+	b, err = yaml.Marshal(em)
+	require.NoError(t, err)
+
+	var fromYAML Embedded
+	require.NoError(t, yaml.Unmarshal(b, &fromYAML))
+	assert.True(t, em.When.Equal(fromYAML.When))
+}
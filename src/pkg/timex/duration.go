@@ -0,0 +1,119 @@
+package timex
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar-like durations supported by ParseDuration. These are fixed
+// lengths of time, ignoring daylight savings and varying month lengths.
+const (
+	Day   = 24 * time.Hour
+	Week  = 7 * Day
+	Month = 30 * Day
+)
+
+// ParseDuration parses a duration string. In addition to the units supported
+// by time.ParseDuration, the duration can use "d" for days, "w" for weeks,
+// and "mo" for 30 day months, e.g. "3d", "2w", "1mo", or "1d12h".
+func ParseDuration(s string) (time.Duration, error) {
+	orig := s
+	neg := false
+	if s != "" && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+
+	if s == "0" {
+		return 0, nil
+	}
+
+	if s == "" {
+		return 0, fmt.Errorf("invalid duration '%s'", orig)
+	}
+
+	var d time.Duration
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration '%s'", orig)
+		}
+
+		j := strings.IndexFunc(s[i:], func(r rune) bool { return (r >= '0' && r <= '9') || r == '.' })
+		if j < 0 {
+			j = len(s) - i
+		}
+
+		number, unit := s[:i], s[i:i+j]
+		s = s[i+j:]
+
+		var unitDuration time.Duration
+		switch unit {
+		case "d":
+			unitDuration = Day
+		case "w":
+			unitDuration = Week
+		case "mo":
+			unitDuration = Month
+		default:
+			part, err := time.ParseDuration(number + unit)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration '%s': %w", orig, err)
+			}
+
+			d += part
+			continue
+		}
+
+		n, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s': %w", orig, err)
+		}
+
+		d += time.Duration(n * float64(unitDuration))
+	}
+
+	if neg {
+		return -d, nil
+	}
+
+	return d, nil
+}
+
+// FormatDuration formats a duration in days, hours, minutes, and seconds,
+// omitting any that are zero, e.g. "2d4h" or "1h30m". Unlike
+// time.Duration.String, durations longer than a day are written in days.
+func FormatDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+
+	var sb strings.Builder
+	if d < 0 {
+		sb.WriteByte('-')
+		d = -d
+	}
+
+	for _, unit := range []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"d", Day},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	} {
+		if n := d / unit.duration; n > 0 {
+			sb.WriteString(strconv.FormatInt(int64(n), 10))
+			sb.WriteString(unit.suffix)
+			d -= n * unit.duration
+		}
+	}
+
+	if d > 0 {
+		sb.WriteString(d.String())
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package timex
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDuration(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want time.Duration
+	}{
+		{"0", 0},
+		{"3d", 3 * Day},
+		{"2w", 14 * Day},
+		{"1mo", 30 * Day},
+		{"1.5d", 36 * time.Hour},
+		{"1d12h", 36 * time.Hour},
+		{"1w2d3h4m5s", Week + 2*Day + 3*time.Hour + 4*time.Minute + 5*time.Second},
+		{"90m", 90 * time.Minute},
+		{"250ms", 250 * time.Millisecond},
+		{"-2d", -2 * Day},
+		{"+2d", 2 * Day},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			d, err := ParseDuration(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, d)
+		})
+	}
+}
+
+func TestParseDuration_Errors(t *testing.T) {
+	for _, s := range []string{"", "-", "d", "3", "3x", "3dd", "1..5d", "3d-"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParseDuration(s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for _, tt := range []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "0s"},
+		{2*Day + 4*time.Hour, "2d4h"},
+		{90 * time.Minute, "1h30m"},
+		{Week, "7d"},
+		{Day + 5*time.Second, "1d5s"},
+		{1500 * time.Millisecond, "1.5s"},
+		{250 * time.Millisecond, "250ms"},
+		{-36 * time.Hour, "-1d12h"},
+	} {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatDuration(tt.d))
+
+			d, err := ParseDuration(tt.want)
+			require.NoError(t, err)
+			assert.Equal(t, tt.d, d)
+		})
+	}
+}
@@ -3,7 +3,13 @@ package timex
 import (
 	"encoding"
 	"fmt"
+	"iter"
+	"slices"
 	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mmihic/golib/src/internal/mathx"
 )
 
 // MonthYear is a month and year combination.
@@ -25,6 +31,11 @@ func ParseMonthYear(s string) (MonthYear, error) {
 	}, nil
 }
 
+// CurrentMonth returns the current month in UTC.
+func CurrentMonth(clock Clock) MonthYear {
+	return TodayUTC(clock).MonthYear()
+}
+
 // MustParseMonthYear parses a month-year, panicking
 // if the month-year cannot be parsed. Useful for tests.
 func MustParseMonthYear(s string) MonthYear {
@@ -48,6 +59,27 @@ func (my *MonthYear) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// MarshalText marshals the MonthYear as a text value.
+// Implements the TextMarshaler interface.
+func (my MonthYear) MarshalText() ([]byte, error) {
+	return []byte(my.String()), nil
+}
+
+// UnmarshalYAML unmarshals the MonthYear from a YAML string.
+func (my *MonthYear) UnmarshalYAML(n *yaml.Node) error {
+	var s string
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return my.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals the MonthYear as a YAML string.
+func (my MonthYear) MarshalYAML() (any, error) {
+	return my.String(), nil
+}
+
 // String returns a string format of the MonthYear
 func (my MonthYear) String() string {
 	return fmt.Sprintf("%04d-%02d", my.Year, my.Month)
@@ -64,28 +96,41 @@ func (my MonthYear) MonthStart() Date {
 
 // MonthEnd returns the date that is the end of the month.
 func (my MonthYear) MonthEnd() Date {
-	// Go to the start of the next month and backoff
-	endOfMonth := my.NextMonth().MonthStart().DayStart().Add(-time.Nanosecond)
 	return Date{
-		Day:   endOfMonth.Day(),
-		Month: endOfMonth.Month(),
-		Year:  endOfMonth.Year(),
+		Day:   my.Days(),
+		Month: my.Month,
+		Year:  my.Year,
 	}
 }
 
-// AddMonths returns the next numMonths out.
+// Days returns the number of days in the month.
+func (my MonthYear) Days() int {
+	// Day 0 of the next month normalizes to the last day of this month
+	return time.Date(my.Year, my.Month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// IsLeapYear returns true if the year is a leap year in the Gregorian calendar.
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// AddMonths returns the next numMonths out. numMonths can be negative.
 func (my MonthYear) AddMonths(numMonths int) MonthYear {
-	month := time.Month(int(my.Month) + (numMonths % 12))
-	year := my.Year + (numMonths / 12)
-	if month == 0 {
-		month = time.December
-		year--
-	} else if month > 12 {
-		month = month % 12
-		year++
-	}
+	return FromMonthIndex(my.MonthIndex() + numMonths)
+}
+
+// MonthIndex returns the number of months since January of year 0. Month
+// indices make month arithmetic exact: the MonthYear n months out is
+// FromMonthIndex(my.MonthIndex() + n).
+func (my MonthYear) MonthIndex() int {
+	return my.Year*12 + int(my.Month) - 1
+}
+
+// FromMonthIndex returns the MonthYear for a month index, as returned by MonthIndex.
+func FromMonthIndex(index int) MonthYear {
+	year := mathx.FloorDiv(index, 12)
 	return MonthYear{
-		Month: month,
+		Month: time.Month(index-year*12) + 1,
 		Year:  year,
 	}
 }
@@ -100,6 +145,14 @@ func (my MonthYear) NextMonth() MonthYear {
 	return my.AddMonths(1)
 }
 
+// Quarter returns the quarter containing the month.
+func (my MonthYear) Quarter() Quarter {
+	return Quarter{
+		Quarter: (int(my.Month)-1)/3 + 1,
+		Year:    my.Year,
+	}
+}
+
 // IsZero returns true if the month/year is not set.
 func (my MonthYear) IsZero() bool {
 	return my.Month == 0 && my.Year == 0
@@ -153,7 +206,7 @@ func (my MonthYear) Less(other MonthYear) bool {
 
 // MonthsBetween returns the number of months between two (month, year)
 func MonthsBetween(from, to MonthYear) int {
-	monthsBetween := (from.Year*12 + int(from.Month)) - (to.Year*12 + int(to.Month))
+	monthsBetween := from.MonthIndex() - to.MonthIndex()
 	if monthsBetween < 0 {
 		return -monthsBetween
 	}
@@ -161,6 +214,91 @@ func MonthsBetween(from, to MonthYear) int {
 	return monthsBetween
 }
 
+// MonthsRange returns an iterator over the months from one month to
+// another, inclusive. The iterator is empty if to is before from.
+func MonthsRange(from, to MonthYear) iter.Seq[MonthYear] {
+	return func(yield func(MonthYear) bool) {
+		for my := from; !my.After(to); my = my.NextMonth() {
+			if !yield(my) {
+				return
+			}
+		}
+	}
+}
+
+// MonthsInRange returns the months from one month to another, inclusive.
+func MonthsInRange(from, to MonthYear) []MonthYear {
+	months := make([]MonthYear, 0, max(0, MonthsBetween(from, to)+1))
+	for my := range MonthsRange(from, to) {
+		months = append(months, my)
+	}
+
+	return months
+}
+
+// EachMonth calls fn for each month from one month to another, inclusive,
+// stopping at the first error.
+func EachMonth(from, to MonthYear, fn func(my MonthYear) error) error {
+	for my := range MonthsRange(from, to) {
+		if err := fn(my); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Compare compares this MonthYear to another, following the conventions
+// of cmp.Compare, so that MonthYears can be sorted with slices.SortFunc.
+func (my MonthYear) Compare(other MonthYear) int {
+	return my.CompareTo(other)
+}
+
+// Clamp returns the MonthYear bounded to the range [lo, hi].
+func (my MonthYear) Clamp(lo, hi MonthYear) MonthYear {
+	if my.Before(lo) {
+		return lo
+	}
+
+	if my.After(hi) {
+		return hi
+	}
+
+	return my
+}
+
+// MinMonthYear returns the earliest of the given MonthYears.
+func MinMonthYear(first MonthYear, rest ...MonthYear) MonthYear {
+	earliest := first
+	for _, my := range rest {
+		if my.Before(earliest) {
+			earliest = my
+		}
+	}
+
+	return earliest
+}
+
+// MaxMonthYear returns the latest of the given MonthYears.
+func MaxMonthYear(first MonthYear, rest ...MonthYear) MonthYear {
+	latest := first
+	for _, my := range rest {
+		if my.After(latest) {
+			latest = my
+		}
+	}
+
+	return latest
+}
+
+// SortMonthYears sorts MonthYears in ascending order.
+func SortMonthYears(values []MonthYear) {
+	slices.SortFunc(values, MonthYear.Compare)
+}
+
 var (
 	_ encoding.TextUnmarshaler = &MonthYear{}
+	_ encoding.TextMarshaler   = MonthYear{}
+	_ yaml.Unmarshaler         = &MonthYear{}
+	_ yaml.Marshaler           = MonthYear{}
 )
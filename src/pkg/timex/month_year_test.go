@@ -3,11 +3,13 @@ package timex
 import (
 	"encoding/json"
 	"fmt"
+	"slices"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestParseMonthYear(t *testing.T) {
@@ -140,6 +142,50 @@ func TestMonthYear_AddMonths(t *testing.T) {
 	}
 }
 
+func TestMonthYear_AddMonthsAcrossYears(t *testing.T) {
+	for _, tt := range []struct {
+		start     string
+		numMonths int
+		want      string
+	}{
+		{"2021-01", -1, "2020-12"},
+		{"2021-01", -2, "2020-11"},
+		{"2021-03", -26, "2019-01"},
+		{"2021-03", -27, "2018-12"},
+		{"2021-11", 26, "2024-01"},
+		{"2021-12", 12, "2022-12"},
+		{"2021-12", 13, "2023-01"},
+		{"2021-06", 1200, "2121-06"},
+		{"2021-06", -1200, "1921-06"},
+	} {
+		t.Run(fmt.Sprintf("%s+%d", tt.start, tt.numMonths), func(t *testing.T) {
+			start, want := MustParseMonthYear(tt.start), MustParseMonthYear(tt.want)
+			assert.Equal(t, want, start.AddMonths(tt.numMonths))
+			assert.Equal(t, start, want.AddMonths(-tt.numMonths))
+			assert.Equal(t, max(tt.numMonths, -tt.numMonths), MonthsBetween(start, want))
+		})
+	}
+}
+
+func TestMonthYear_MonthIndex(t *testing.T) {
+	for _, tt := range []struct {
+		my    MonthYear
+		index int
+	}{
+		{MonthYear{Month: time.January, Year: 0}, 0},
+		{MonthYear{Month: time.December, Year: 0}, 11},
+		{MonthYear{Month: time.January, Year: 1}, 12},
+		{MonthYear{Month: time.December, Year: -1}, -1},
+		{MonthYear{Month: time.January, Year: -1}, -12},
+		{MustParseMonthYear("2024-03"), 2024*12 + 2},
+	} {
+		t.Run(tt.my.String(), func(t *testing.T) {
+			assert.Equal(t, tt.index, tt.my.MonthIndex())
+			assert.Equal(t, tt.my, FromMonthIndex(tt.index))
+		})
+	}
+}
+
 func TestMonthYear_NextMonth(t *testing.T) {
 	actual := MustParseMonthYear("2021-10")
 	for _, want := range []MonthYear{
@@ -195,3 +241,118 @@ func TestMonthYearJSON(t *testing.T) {
 		When: MonthYear{Year: 2022, Month: time.September},
 	}, em)
 }
+
+func TestMonthYearJSON_RoundTrip(t *testing.T) {
+	type Embedded struct {
+		When  MonthYear  `json:"when"`
+		Maybe *MonthYear `json:"maybe"`
+	}
+
+	when := MonthYear{Year: 2022, Month: time.September}
+	b, err := json.Marshal(Embedded{When: when, Maybe: &when})
+	require.NoError(t, err)
+	assert.Equal(t, `{"when":"2022-09","maybe":"2022-09"}`, string(b))
+
+	var em Embedded
+	require.NoError(t, json.Unmarshal(b, &em))
+	assert.Equal(t, Embedded{When: when, Maybe: &when}, em)
+}
+
+func TestMonthYearYAML(t *testing.T) {
+	type Embedded struct {
+		When MonthYear `yaml:"when"`
+	}
+
+	b, err := yaml.Marshal(Embedded{When: MonthYear{Year: 2022, Month: time.September}})
+	require.NoError(t, err)
+	assert.Equal(t, "when: 2022-09\n", string(b))
+
+	var em Embedded
+	require.NoError(t, yaml.Unmarshal(b, &em))
+	assert.Equal(t, Embedded{When: MonthYear{Year: 2022, Month: time.September}}, em)
+
+	err = yaml.Unmarshal([]byte("when: bad"), &em)
+	assert.Error(t, err)
+}
+
+func TestMonthsRange(t *testing.T) {
+	from, to := MustParseMonthYear("2023-11"), MustParseMonthYear("2024-02")
+	want := []MonthYear{
+		MustParseMonthYear("2023-11"),
+		MustParseMonthYear("2023-12"),
+		MustParseMonthYear("2024-01"),
+		MustParseMonthYear("2024-02"),
+	}
+
+	assert.Equal(t, want, slices.Collect(MonthsRange(from, to)))
+	assert.Equal(t, want, MonthsInRange(from, to))
+	assert.Equal(t, []MonthYear{from}, MonthsInRange(from, from))
+	assert.Empty(t, MonthsInRange(to, from))
+
+	var visited []MonthYear
+	require.NoError(t, EachMonth(from, to, func(my MonthYear) error {
+		visited = append(visited, my)
+		return nil
+	}))
+	assert.Equal(t, want, visited)
+
+	visited = nil
+	err := EachMonth(from, to, func(my MonthYear) error {
+		if my.Month == time.January {
+			return fmt.Errorf("stop at %s", my)
+		}
+
+		visited = append(visited, my)
+		return nil
+	})
+	assert.EqualError(t, err, "stop at 2024-01")
+	assert.Equal(t, want[:2], visited)
+}
+
+func TestMonthYear_MinMaxClampSort(t *testing.T) {
+	var (
+		m1 = MustParseMonthYear("2023-10")
+		m2 = MustParseMonthYear("2023-12")
+		m3 = MustParseMonthYear("2024-01")
+	)
+
+	assert.Equal(t, m1, MinMonthYear(m2, m3, m1))
+	assert.Equal(t, m3, MaxMonthYear(m2, m3, m1))
+
+	assert.Equal(t, m2, m2.Clamp(m1, m3))
+	assert.Equal(t, m2, m1.Clamp(m2, m3))
+	assert.Equal(t, m2, m3.Clamp(m1, m2))
+
+	months := []MonthYear{m3, m1, m2}
+	SortMonthYears(months)
+	assert.Equal(t, []MonthYear{m1, m2, m3}, months)
+}
+
+func TestMonthYear_Days(t *testing.T) {
+	for _, tt := range []struct {
+		my   string
+		want int
+	}{
+		{"2024-01", 31},
+		{"2024-02", 29},
+		{"2023-02", 28},
+		{"2000-02", 29},
+		{"1900-02", 28},
+		{"2024-04", 30},
+		{"2024-12", 31},
+	} {
+		assert.Equal(t, tt.want, MustParseMonthYear(tt.my).Days(), tt.my)
+	}
+}
+
+func TestIsLeapYear(t *testing.T) {
+	for year, want := range map[int]bool{
+		2023: false,
+		2024: true,
+		1900: false,
+		2000: true,
+		2100: false,
+	} {
+		assert.Equal(t, want, IsLeapYear(year), year)
+	}
+}
@@ -0,0 +1,199 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Period is a calendar period of years, months, and days, such as a
+// billing interval. Unlike a time.Duration, adding a Period to a Date
+// respects month lengths and leap years. Periods are written as ISO 8601
+// durations without a time component, e.g. P1Y2M3D, P1M, or P2W.
+type Period struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// ParsePeriod parses an ISO 8601 period, e.g. P1Y2M3D. Weeks (e.g. P2W)
+// are converted to days, and the period can be negated with a leading "-".
+// Individual components can also be negative, e.g. P1M-1D, so that periods
+// with mixed signs can be written. Time components (e.g. PT1H) are not
+// supported.
+func ParsePeriod(s string) (Period, error) {
+	orig := s
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	s, ok := strings.CutPrefix(s, "P")
+	if !ok || s == "" {
+		return Period{}, fmt.Errorf("invalid period '%s'", orig)
+	}
+
+	var p Period
+	designators := "YMWD"
+	for s != "" {
+		i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if i == 0 && s[0] == '-' {
+			// A negative component
+			i = strings.IndexFunc(s[1:], func(r rune) bool { return r < '0' || r > '9' }) + 1
+		}
+
+		if i <= 0 || s[i-1] == '-' {
+			return Period{}, fmt.Errorf("invalid period '%s'", orig)
+		}
+
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return Period{}, fmt.Errorf("invalid period '%s': %w", orig, err)
+		}
+
+		// Designators must appear at most once, in order
+		designator := s[i]
+		pos := strings.IndexByte(designators, designator)
+		if pos < 0 {
+			return Period{}, fmt.Errorf("invalid period '%s'", orig)
+		}
+
+		designators = designators[pos+1:]
+		switch designator {
+		case 'Y':
+			p.Years = n
+		case 'M':
+			p.Months = n
+		case 'W':
+			p.Days += n * 7
+		case 'D':
+			p.Days += n
+		}
+
+		s = s[i+1:]
+	}
+
+	if neg {
+		return p.Negate(), nil
+	}
+
+	return p, nil
+}
+
+// MustParsePeriod parses a period, panicking if the period
+// cannot be parsed. Useful for tests.
+func MustParsePeriod(s string) Period {
+	p, err := ParsePeriod(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+// String returns the period in ISO 8601 format. If all of the components
+// are negative, the period is written with a leading "-", otherwise each
+// negative component is written with its own sign, e.g. P1M-1D.
+func (p Period) String() string {
+	if p.IsZero() {
+		return "P0D"
+	}
+
+	var sb strings.Builder
+	if p.Years <= 0 && p.Months <= 0 && p.Days <= 0 {
+		sb.WriteByte('-')
+		p = p.Negate()
+	}
+
+	sb.WriteByte('P')
+	for _, c := range []struct {
+		n          int
+		designator byte
+	}{
+		{p.Years, 'Y'},
+		{p.Months, 'M'},
+		{p.Days, 'D'},
+	} {
+		if c.n != 0 {
+			sb.WriteString(strconv.Itoa(c.n))
+			sb.WriteByte(c.designator)
+		}
+	}
+
+	return sb.String()
+}
+
+// IsZero returns true if the period is empty.
+func (p Period) IsZero() bool {
+	return p.Years == 0 && p.Months == 0 && p.Days == 0
+}
+
+// Negate returns the period with all of its components negated.
+func (p Period) Negate() Period {
+	return Period{Years: -p.Years, Months: -p.Months, Days: -p.Days}
+}
+
+// AddTo adds the period to a date. The years and months are added first,
+// using the last day of the month if the day does not exist in the resulting
+// month, followed by the days.
+func (p Period) AddTo(d Date) Date {
+	return d.AddMonths(p.Years*12 + p.Months).AddDays(p.Days)
+}
+
+// AddToMonthYear adds the years and months of the period to a MonthYear,
+// ignoring the days.
+func (p Period) AddToMonthYear(my MonthYear) MonthYear {
+	return my.AddMonths(p.Years*12 + p.Months)
+}
+
+// UnmarshalText unmarshalls the period from a text value.
+// Implements the TextUnmarshaler interface.
+func (p *Period) UnmarshalText(text []byte) error {
+	u, err := ParsePeriod(string(text))
+	if err != nil {
+		return err
+	}
+
+	*p = u
+	return nil
+}
+
+// MarshalText marshals the period as a text value.
+// Implements the TextMarshaler interface.
+func (p Period) MarshalText() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalYAML unmarshals the period from a YAML string.
+func (p *Period) UnmarshalYAML(n *yaml.Node) error {
+	var s string
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return p.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals the period as a YAML string.
+func (p Period) MarshalYAML() (any, error) {
+	return p.String(), nil
+}
+
+// AddPeriod adds a period to the date.
+func (d Date) AddPeriod(p Period) Date {
+	return p.AddTo(d)
+}
+
+// AddPeriod adds the years and months of a period to the MonthYear,
+// ignoring the days.
+func (my MonthYear) AddPeriod(p Period) MonthYear {
+	return p.AddToMonthYear(my)
+}
+
+var (
+	_ encoding.TextUnmarshaler = &Period{}
+	_ encoding.TextMarshaler   = Period{}
+	_ yaml.Unmarshaler         = &Period{}
+	_ yaml.Marshaler           = Period{}
+)
@@ -0,0 +1,102 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePeriod(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want Period
+		str  string
+	}{
+		{"P1Y2M3D", Period{Years: 1, Months: 2, Days: 3}, "P1Y2M3D"},
+		{"P1M", Period{Months: 1}, "P1M"},
+		{"P2W", Period{Days: 14}, "P14D"},
+		{"P1Y2W3D", Period{Years: 1, Days: 17}, "P1Y17D"},
+		{"P0D", Period{}, "P0D"},
+		{"-P1Y6M", Period{Years: -1, Months: -6}, "-P1Y6M"},
+		{"P-1D", Period{Days: -1}, "-P1D"},
+		{"P1M-1D", Period{Months: 1, Days: -1}, "P1M-1D"},
+		{"-P1M-1D", Period{Months: -1, Days: 1}, "P-1M1D"},
+		{"P-1Y2M-2W", Period{Years: -1, Months: 2, Days: -14}, "P-1Y2M-14D"},
+	} {
+		t.Run(tt.s, func(t *testing.T) {
+			p, err := ParsePeriod(tt.s)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p)
+			assert.Equal(t, tt.str, p.String())
+		})
+	}
+}
+
+func TestParsePeriod_Errors(t *testing.T) {
+	for _, s := range []string{"", "P", "1Y", "PY", "P1", "P1D1Y", "P1Y1Y", "PT1H", "P1X", "P-D", "P--1D", "P1-D", "--P1D", "P1.5D"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := ParsePeriod(s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPeriod_RoundTrip(t *testing.T) {
+	for _, p := range []Period{
+		{Years: 1, Months: -1},
+		{Months: 1, Days: -1},
+		{Years: -2, Days: 3},
+		{Years: -1, Months: 2, Days: -3},
+		{Years: 1, Months: -2, Days: 3},
+		{Years: -1, Months: -2, Days: -3},
+		{Days: -7},
+	} {
+		t.Run(p.String(), func(t *testing.T) {
+			parsed, err := ParsePeriod(p.String())
+			require.NoError(t, err)
+			assert.Equal(t, p, parsed)
+		})
+	}
+}
+
+func TestPeriod_AddTo(t *testing.T) {
+	for _, tt := range []struct {
+		date   string
+		period string
+		want   string
+	}{
+		{"2024-01-15", "P1M", "2024-02-15"},
+		{"2024-01-31", "P1M", "2024-02-29"},
+		{"2024-01-31", "P1M1D", "2024-03-01"},
+		{"2024-02-29", "P1Y", "2025-02-28"},
+		{"2024-01-15", "P1Y2M3D", "2025-03-18"},
+		{"2024-01-15", "P2W", "2024-01-29"},
+		{"2024-03-31", "-P1M", "2024-02-29"},
+		{"2024-01-15", "P26M", "2026-03-15"},
+	} {
+		t.Run(tt.date+"+"+tt.period, func(t *testing.T) {
+			p := MustParsePeriod(tt.period)
+			assert.Equal(t, MustParseDate(tt.want), p.AddTo(MustParseDate(tt.date)))
+			assert.Equal(t, MustParseDate(tt.want), MustParseDate(tt.date).AddPeriod(p))
+		})
+	}
+
+	assert.Equal(t, MustParseMonthYear("2025-03"),
+		MustParseMonthYear("2024-01").AddPeriod(MustParsePeriod("P1Y2M3D")))
+}
+
+func TestPeriodJSON(t *testing.T) {
+	type Subscription struct {
+		Interval Period `json:"interval"`
+	}
+
+	b, err := json.Marshal(Subscription{Interval: Period{Months: 3}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"interval":"P3M"}`, string(b))
+
+	var sub Subscription
+	require.NoError(t, json.Unmarshal([]byte(`{"interval":"P1Y"}`), &sub))
+	assert.Equal(t, Subscription{Interval: Period{Years: 1}}, sub)
+}
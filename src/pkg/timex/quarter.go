@@ -0,0 +1,201 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mmihic/golib/src/internal/mathx"
+)
+
+// A Quarter is a quarter of a year, e.g. 2024-Q3.
+type Quarter struct {
+	Quarter int
+	Year    int
+}
+
+// ParseQuarter parses a quarter in the form 2024-Q3.
+func ParseQuarter(s string) (Quarter, error) {
+	year, quarter, ok := strings.Cut(s, "-Q")
+	if !ok || len(year) != 4 || len(quarter) != 1 {
+		return Quarter{}, fmt.Errorf("invalid quarter '%s'", s)
+	}
+
+	y, err := strconv.Atoi(year)
+	if err != nil {
+		return Quarter{}, fmt.Errorf("invalid quarter '%s'", s)
+	}
+
+	q, err := strconv.Atoi(quarter)
+	if err != nil || q < 1 || q > 4 {
+		return Quarter{}, fmt.Errorf("invalid quarter '%s'", s)
+	}
+
+	return Quarter{Quarter: q, Year: y}, nil
+}
+
+// MustParseQuarter parses a quarter, panicking if the quarter
+// cannot be parsed. Useful for tests.
+func MustParseQuarter(s string) Quarter {
+	q, err := ParseQuarter(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+// UnmarshalText unmarshalls the quarter from a text value.
+// Implements the TextUnmarshaler interface.
+func (q *Quarter) UnmarshalText(text []byte) error {
+	u, err := ParseQuarter(string(text))
+	if err != nil {
+		return err
+	}
+
+	*q = u
+	return nil
+}
+
+// MarshalText marshals the quarter as a text value.
+// Implements the TextMarshaler interface.
+func (q Quarter) MarshalText() ([]byte, error) {
+	return []byte(q.String()), nil
+}
+
+// UnmarshalYAML unmarshals the quarter from a YAML string.
+func (q *Quarter) UnmarshalYAML(n *yaml.Node) error {
+	var s string
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return q.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals the quarter as a YAML string.
+func (q Quarter) MarshalYAML() (any, error) {
+	return q.String(), nil
+}
+
+// String returns a string format of the quarter.
+func (q Quarter) String() string {
+	return fmt.Sprintf("%04d-Q%d", q.Year, q.Quarter)
+}
+
+// FirstMonth returns the first month of the quarter.
+func (q Quarter) FirstMonth() MonthYear {
+	return MonthYear{
+		Month: time.Month((q.Quarter-1)*3 + 1),
+		Year:  q.Year,
+	}
+}
+
+// LastMonth returns the last month of the quarter.
+func (q Quarter) LastMonth() MonthYear {
+	return MonthYear{
+		Month: time.Month(q.Quarter * 3),
+		Year:  q.Year,
+	}
+}
+
+// QuarterStart returns the date that is the start of the quarter.
+func (q Quarter) QuarterStart() Date {
+	return q.FirstMonth().MonthStart()
+}
+
+// QuarterEnd returns the date that is the end of the quarter.
+func (q Quarter) QuarterEnd() Date {
+	return q.LastMonth().MonthEnd()
+}
+
+// AddQuarters returns the quarter numQuarters out.
+func (q Quarter) AddQuarters(numQuarters int) Quarter {
+	index := q.Year*4 + q.Quarter - 1 + numQuarters
+	year := mathx.FloorDiv(index, 4)
+	return Quarter{
+		Quarter: index - year*4 + 1,
+		Year:    year,
+	}
+}
+
+// PriorQuarter returns the prior quarter.
+func (q Quarter) PriorQuarter() Quarter {
+	return q.AddQuarters(-1)
+}
+
+// NextQuarter returns the next quarter.
+func (q Quarter) NextQuarter() Quarter {
+	return q.AddQuarters(1)
+}
+
+// IsZero returns true if the quarter is not set.
+func (q Quarter) IsZero() bool {
+	return q.Quarter == 0 && q.Year == 0
+}
+
+// After returns true if this quarter is after another.
+func (q Quarter) After(other Quarter) bool {
+	return q.CompareTo(other) > 0
+}
+
+// Before returns true if this quarter is before another.
+func (q Quarter) Before(other Quarter) bool {
+	return q.CompareTo(other) < 0
+}
+
+// Equal returns true if this quarter is equal to another.
+func (q Quarter) Equal(other Quarter) bool {
+	return q.CompareTo(other) == 0
+}
+
+// CompareTo compares two quarters. Returns:
+//
+//	-1 if this Quarter is earlier than the provided Quarter
+//	1 if this Quarter is later than the provided Quarter
+//	0 if this Quarter is the same as the provided Quarter
+func (q Quarter) CompareTo(other Quarter) int {
+	if q.Year > other.Year {
+		return 1
+	}
+
+	if q.Year < other.Year {
+		return -1
+	}
+
+	if q.Quarter > other.Quarter {
+		return 1
+	}
+
+	if q.Quarter < other.Quarter {
+		return -1
+	}
+
+	return 0
+}
+
+// Less returns true if the Quarter is earlier than the provided Quarter.
+func (q Quarter) Less(other Quarter) bool {
+	return q.CompareTo(other) < 0
+}
+
+// QuartersBetween returns the number of quarters between two quarters.
+func QuartersBetween(from, to Quarter) int {
+	quartersBetween := (from.Year*4 + from.Quarter) - (to.Year*4 + to.Quarter)
+	if quartersBetween < 0 {
+		return -quartersBetween
+	}
+
+	return quartersBetween
+}
+
+var (
+	_ encoding.TextUnmarshaler = &Quarter{}
+	_ encoding.TextMarshaler   = Quarter{}
+	_ yaml.Unmarshaler         = &Quarter{}
+	_ yaml.Marshaler           = Quarter{}
+)
@@ -0,0 +1,110 @@
+package timex
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuarter(t *testing.T) {
+	q, err := ParseQuarter("2024-Q3")
+	require.NoError(t, err)
+	assert.Equal(t, Quarter{Quarter: 3, Year: 2024}, q)
+	assert.Equal(t, "2024-Q3", q.String())
+
+	for _, s := range []string{"2024-Q0", "2024-Q5", "2024-3", "24-Q3", "2024-Q33", "abcd-Q1", "2024-Qx"} {
+		_, err := ParseQuarter(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestQuarter_StartEnd(t *testing.T) {
+	for _, tt := range []struct {
+		quarter    string
+		firstMonth string
+		lastMonth  string
+		start      string
+		end        string
+	}{
+		{"2024-Q1", "2024-01", "2024-03", "2024-01-01", "2024-03-31"},
+		{"2024-Q2", "2024-04", "2024-06", "2024-04-01", "2024-06-30"},
+		{"2024-Q3", "2024-07", "2024-09", "2024-07-01", "2024-09-30"},
+		{"2024-Q4", "2024-10", "2024-12", "2024-10-01", "2024-12-31"},
+	} {
+		t.Run(tt.quarter, func(t *testing.T) {
+			q := MustParseQuarter(tt.quarter)
+			assert.Equal(t, MustParseMonthYear(tt.firstMonth), q.FirstMonth())
+			assert.Equal(t, MustParseMonthYear(tt.lastMonth), q.LastMonth())
+			assert.Equal(t, MustParseDate(tt.start), q.QuarterStart())
+			assert.Equal(t, MustParseDate(tt.end), q.QuarterEnd())
+			assert.Equal(t, q, q.FirstMonth().Quarter())
+			assert.Equal(t, q, q.LastMonth().Quarter())
+			assert.Equal(t, q, q.QuarterEnd().Quarter())
+		})
+	}
+}
+
+func TestQuarter_AddQuarters(t *testing.T) {
+	for _, tt := range []struct {
+		start    string
+		quarters int
+		want     string
+	}{
+		{"2024-Q3", 0, "2024-Q3"},
+		{"2024-Q3", 1, "2024-Q4"},
+		{"2024-Q4", 1, "2025-Q1"},
+		{"2024-Q3", 6, "2026-Q1"},
+		{"2024-Q1", -1, "2023-Q4"},
+		{"2024-Q2", -9, "2022-Q1"},
+	} {
+		t.Run(fmt.Sprintf("%s+%d", tt.start, tt.quarters), func(t *testing.T) {
+			start, want := MustParseQuarter(tt.start), MustParseQuarter(tt.want)
+			assert.Equal(t, want, start.AddQuarters(tt.quarters))
+			assert.Equal(t, max(tt.quarters, -tt.quarters), QuartersBetween(start, want))
+		})
+	}
+
+	q := MustParseQuarter("2024-Q1")
+	assert.Equal(t, MustParseQuarter("2024-Q2"), q.NextQuarter())
+	assert.Equal(t, MustParseQuarter("2023-Q4"), q.PriorQuarter())
+}
+
+func TestQuarter_CompareTo(t *testing.T) {
+	baseline := MustParseQuarter("2024-Q3")
+	for _, tt := range []struct {
+		other string
+		want  int
+	}{
+		{"2023-Q4", 1},
+		{"2025-Q1", -1},
+		{"2024-Q2", 1},
+		{"2024-Q4", -1},
+		{"2024-Q3", 0},
+	} {
+		t.Run(fmt.Sprintf("%s vs %s", baseline, tt.other), func(t *testing.T) {
+			other := MustParseQuarter(tt.other)
+			assert.Equal(t, tt.want, baseline.CompareTo(other))
+			assert.Equal(t, tt.want < 0, baseline.Less(other))
+			assert.Equal(t, tt.want < 0, baseline.Before(other))
+			assert.Equal(t, tt.want > 0, baseline.After(other))
+			assert.Equal(t, tt.want == 0, baseline.Equal(other))
+		})
+	}
+}
+
+func TestQuarterJSON(t *testing.T) {
+	type Embedded struct {
+		When Quarter `json:"when"`
+	}
+
+	b, err := json.Marshal(Embedded{When: MustParseQuarter("2024-Q3")})
+	require.NoError(t, err)
+	assert.Equal(t, `{"when":"2024-Q3"}`, string(b))
+
+	var em Embedded
+	require.NoError(t, json.Unmarshal(b, &em))
+	assert.Equal(t, Embedded{When: Quarter{Quarter: 3, Year: 2024}}, em)
+}
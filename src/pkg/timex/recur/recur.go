@@ -0,0 +1,281 @@
+// Package recur schedules recurring events, such as "every 2 weeks on Monday"
+// or "every month on the last Friday".
+package recur
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"time"
+
+	"github.com/mmihic/golib/src/internal/mathx"
+	"github.com/mmihic/golib/src/pkg/timex"
+)
+
+// A Frequency is the unit of time between recurrences.
+type Frequency int
+
+// Supported frequencies
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+)
+
+// String returns the name of the frequency.
+func (f Frequency) String() string {
+	switch f {
+	case Daily:
+		return "daily"
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	default:
+		return fmt.Sprintf("Frequency(%d)", int(f))
+	}
+}
+
+// A Rule describes when an event recurs.
+type Rule struct {
+	// Frequency is the unit of time between recurrences.
+	Frequency Frequency
+
+	// Interval is the number of units between recurrences, e.g. an Interval
+	// of 2 with a Weekly Frequency is every other week. Defaults to 1.
+	Interval int
+
+	// Start is the first date the event can occur on. Intervals are counted
+	// from the day, week, or month of the Start date.
+	Start timex.Date
+
+	// Weekdays are the days of the week a Weekly event occurs on. Defaults
+	// to the day of the week of the Start date.
+	Weekdays []time.Weekday
+
+	// Nth and Weekday pick the day of the month a Monthly event occurs on,
+	// e.g. an Nth of 2 and a Weekday of Tuesday is the second Tuesday of the
+	// month, and an Nth of -1 is the last Weekday of the month. If Nth is 0,
+	// the event occurs on the day of the month of the Start date, or the last
+	// day of the month for shorter months.
+	Nth     int
+	Weekday time.Weekday
+
+	// TimeOfDay is the time after midnight that the event occurs.
+	TimeOfDay time.Duration
+
+	// Location is the time zone of the event. Defaults to UTC.
+	Location *time.Location
+}
+
+// Validate checks that the rule is valid.
+func (r Rule) Validate() error {
+	if r.Frequency < Daily || r.Frequency > Monthly {
+		return fmt.Errorf("invalid frequency %s", r.Frequency)
+	}
+
+	if r.Interval < 0 {
+		return fmt.Errorf("invalid interval %d", r.Interval)
+	}
+
+	if r.Start.IsZero() {
+		return errors.New("start date is required")
+	}
+
+	if r.Nth < -5 || r.Nth > 5 {
+		return fmt.Errorf("invalid nth %d: must be between -5 and 5", r.Nth)
+	}
+
+	if r.TimeOfDay < 0 || r.TimeOfDay >= 24*time.Hour {
+		return fmt.Errorf("invalid time of day %s", r.TimeOfDay)
+	}
+
+	return nil
+}
+
+// NextAfter returns the first occurrence of the event strictly after t.
+func (r Rule) NextAfter(t time.Time) (time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return time.Time{}, err
+	}
+
+	for next := range r.After(t) {
+		return next, nil
+	}
+
+	return time.Time{}, fmt.Errorf("no occurrences after %s", t.Format(time.RFC3339))
+}
+
+// Next returns the next occurrence of the event after the current time.
+func (r Rule) Next(clock timex.Clock) (time.Time, error) {
+	return r.NextAfter(clock.Now())
+}
+
+// Upcoming returns the next n occurrences of the event after the current time.
+func (r Rule) Upcoming(clock timex.Clock, n int) ([]time.Time, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	upcoming := make([]time.Time, 0, n)
+	for next := range r.After(clock.Now()) {
+		if len(upcoming) == n {
+			break
+		}
+
+		upcoming = append(upcoming, next)
+	}
+
+	return upcoming, nil
+}
+
+// After returns an iterator over the occurrences of the event strictly after t,
+// in order. The iterator is unbounded; callers should stop iterating once they
+// have enough occurrences. An invalid rule has no occurrences. Some valid rules
+// never occur (e.g. the 5th Monday of February every 4 years, which never lands
+// on a leap year); since the calendar repeats every 400 years, the iterator
+// ends once 400 years pass without an occurrence.
+func (r Rule) After(t time.Time) iter.Seq[time.Time] {
+	return func(yield func(time.Time) bool) {
+		if r.Validate() != nil {
+			return
+		}
+
+		interval := max(r.Interval, 1)
+		loc := r.location()
+
+		// Start from the first period containing an occurrence that
+		// could be after t, rather than iterating from the start date.
+		first := max(0, mathx.FloorDiv(r.period(timex.DateFromTimeIn(t, loc)), interval)*interval)
+		horizon := r.horizon()
+		for period, lastFound := first, first; period-lastFound <= horizon; period += interval {
+			for _, d := range r.datesIn(period) {
+				if d.Before(r.Start) {
+					continue
+				}
+
+				lastFound = period
+				occurrence := r.at(d, loc)
+				if occurrence.After(t) && !yield(occurrence) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (r Rule) location() *time.Location {
+	if r.Location == nil {
+		return time.UTC
+	}
+
+	return r.Location
+}
+
+// horizon returns the number of periods in the 400 year cycle of the
+// Gregorian calendar, after which the dates of the rule repeat.
+func (r Rule) horizon() int {
+	switch r.Frequency {
+	case Daily:
+		return daysPer400Years
+	case Weekly:
+		return daysPer400Years/7 + 1
+	default:
+		return 400 * 12
+	}
+}
+
+// daysPer400Years is the number of days in a full cycle of the Gregorian calendar.
+const daysPer400Years = 146097
+
+// period returns the index of the day, week, or month containing the date,
+// relative to the Start date.
+func (r Rule) period(d timex.Date) int {
+	switch r.Frequency {
+	case Daily:
+		return d.Sub(r.Start)
+	case Weekly:
+		return mathx.FloorDiv(d.Sub(r.weekStart()), 7)
+	default:
+		return d.MonthYear().MonthIndex() - r.Start.MonthYear().MonthIndex()
+	}
+}
+
+// datesIn returns the dates in the given period on which the event occurs.
+func (r Rule) datesIn(period int) []timex.Date {
+	switch r.Frequency {
+	case Daily:
+		return []timex.Date{r.Start.AddDays(period)}
+	case Weekly:
+		weekdays := r.Weekdays
+		if len(weekdays) == 0 {
+			weekdays = []time.Weekday{r.Start.Weekday()}
+		}
+
+		weekStart := r.weekStart().AddDays(period * 7)
+		dates := make([]timex.Date, 0, len(weekdays))
+		for _, wd := range weekdays {
+			dates = append(dates, weekStart.AddDays(int(wd)))
+		}
+
+		slices.SortFunc(dates, timex.Date.Compare)
+		return slices.CompactFunc(dates, timex.Date.Equal)
+	default:
+		my := r.Start.MonthYear().AddMonths(period)
+		if r.Nth == 0 {
+			return []timex.Date{{
+				Day:   min(r.Start.Day, my.Days()),
+				Month: my.Month,
+				Year:  my.Year,
+			}}
+		}
+
+		if d, ok := NthWeekday(my, r.Weekday, r.Nth); ok {
+			return []timex.Date{d}
+		}
+
+		return nil
+	}
+}
+
+// weekStart returns the Sunday starting the week of the Start date.
+func (r Rule) weekStart() timex.Date {
+	return r.Start.AddDays(-int(r.Start.Weekday()))
+}
+
+// at returns the time the event occurs on the given date.
+func (r Rule) at(d timex.Date, loc *time.Location) time.Time {
+	hours := r.TimeOfDay / time.Hour
+	minutes := (r.TimeOfDay % time.Hour) / time.Minute
+	seconds := (r.TimeOfDay % time.Minute) / time.Second
+	nanos := r.TimeOfDay % time.Second
+	return time.Date(d.Year, d.Month, d.Day, int(hours), int(minutes), int(seconds), int(nanos), loc)
+}
+
+// NthWeekday returns the nth occurrence of a weekday in a month, e.g. the
+// 2nd Tuesday. A negative n counts from the end of the month, so -1 is the
+// last occurrence. Returns false if the month has no such day, e.g. a 5th
+// Monday in a month with only 4 Mondays.
+func NthWeekday(my timex.MonthYear, weekday time.Weekday, n int) (timex.Date, bool) {
+	if n == 0 {
+		return timex.Date{}, false
+	}
+
+	var d timex.Date
+	if n > 0 {
+		first := my.MonthStart()
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+		d = first.AddDays(offset + (n-1)*7)
+	} else {
+		last := my.MonthEnd()
+		offset := (int(last.Weekday()) - int(weekday) + 7) % 7
+		d = last.AddDays(-offset + (n+1)*7)
+	}
+
+	if d.MonthYear() != my {
+		return timex.Date{}, false
+	}
+
+	return d, true
+}
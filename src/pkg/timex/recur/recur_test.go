@@ -0,0 +1,233 @@
+package recur
+
+import (
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mmihic/golib/src/pkg/timex"
+)
+
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time { return time.Time(c) }
+
+func utc(s string) time.Time {
+	return timex.MustParseTime(time.RFC3339, s)
+}
+
+func TestRule_Upcoming(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		rule Rule
+		now  string
+		want []string
+	}{
+		{
+			"every 3 days",
+			Rule{
+				Frequency: Daily,
+				Interval:  3,
+				Start:     timex.MustParseDate("2024-02-26"),
+			},
+			"2024-02-27T00:00:00Z",
+			[]string{"2024-02-29T00:00:00Z", "2024-03-03T00:00:00Z", "2024-03-06T00:00:00Z"},
+		},
+		{
+			"daily before start",
+			Rule{
+				Frequency: Daily,
+				Start:     timex.MustParseDate("2024-03-01"),
+				TimeOfDay: 9 * time.Hour,
+			},
+			"2024-01-01T00:00:00Z",
+			[]string{"2024-03-01T09:00:00Z", "2024-03-02T09:00:00Z"},
+		},
+		{
+			"daily after time of day",
+			Rule{
+				Frequency: Daily,
+				Start:     timex.MustParseDate("2024-03-01"),
+				TimeOfDay: 9 * time.Hour,
+			},
+			"2024-03-05T09:00:00Z",
+			[]string{"2024-03-06T09:00:00Z", "2024-03-07T09:00:00Z"},
+		},
+		{
+			"every other week on monday and thursday",
+			Rule{
+				Frequency: Weekly,
+				Interval:  2,
+				Start:     timex.MustParseDate("2024-03-05"), // Tuesday
+				Weekdays:  []time.Weekday{time.Thursday, time.Monday},
+			},
+			"2024-03-01T00:00:00Z",
+			[]string{
+				"2024-03-07T00:00:00Z",
+				"2024-03-18T00:00:00Z", "2024-03-21T00:00:00Z",
+				"2024-04-01T00:00:00Z", "2024-04-04T00:00:00Z",
+			},
+		},
+		{
+			"weekly defaults to start weekday",
+			Rule{
+				Frequency: Weekly,
+				Start:     timex.MustParseDate("2024-03-05"),
+			},
+			"2024-03-20T00:00:00Z",
+			[]string{"2024-03-26T00:00:00Z", "2024-04-02T00:00:00Z"},
+		},
+		{
+			"monthly on day of month",
+			Rule{
+				Frequency: Monthly,
+				Start:     timex.MustParseDate("2024-01-31"),
+			},
+			"2024-01-31T00:00:00Z",
+			[]string{"2024-02-29T00:00:00Z", "2024-03-31T00:00:00Z", "2024-04-30T00:00:00Z"},
+		},
+		{
+			"quarterly on the second tuesday",
+			Rule{
+				Frequency: Monthly,
+				Interval:  3,
+				Start:     timex.MustParseDate("2024-01-01"),
+				Nth:       2,
+				Weekday:   time.Tuesday,
+			},
+			"2024-02-01T00:00:00Z",
+			[]string{"2024-04-09T00:00:00Z", "2024-07-09T00:00:00Z", "2024-10-08T00:00:00Z"},
+		},
+		{
+			"monthly on the last friday",
+			Rule{
+				Frequency: Monthly,
+				Start:     timex.MustParseDate("2024-01-01"),
+				Nth:       -1,
+				Weekday:   time.Friday,
+			},
+			"2024-01-26T12:00:00Z",
+			[]string{"2024-02-23T00:00:00Z", "2024-03-29T00:00:00Z"},
+		},
+		{
+			"monthly on the fifth monday skips months without one",
+			Rule{
+				Frequency: Monthly,
+				Start:     timex.MustParseDate("2024-01-01"),
+				Nth:       5,
+				Weekday:   time.Monday,
+			},
+			"2024-01-01T00:00:00Z",
+			[]string{"2024-01-29T00:00:00Z", "2024-04-29T00:00:00Z", "2024-07-29T00:00:00Z"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			upcoming, err := tt.rule.Upcoming(fixedClock(utc(tt.now)), len(tt.want))
+			require.NoError(t, err)
+
+			want := make([]time.Time, 0, len(tt.want))
+			for _, s := range tt.want {
+				want = append(want, utc(s))
+			}
+
+			assert.Equal(t, want, upcoming)
+
+			next, err := tt.rule.Next(fixedClock(utc(tt.now)))
+			require.NoError(t, err)
+			assert.Equal(t, want[0], next)
+		})
+	}
+}
+
+func TestRule_Location(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	rule := Rule{
+		Frequency: Daily,
+		Start:     timex.MustParseDate("2024-03-09"),
+		TimeOfDay: 9*time.Hour + 30*time.Minute,
+		Location:  ny,
+	}
+
+	upcoming, err := rule.Upcoming(fixedClock(utc("2024-03-09T00:00:00Z")), 2)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		time.Date(2024, time.March, 9, 9, 30, 0, 0, ny),
+		time.Date(2024, time.March, 10, 9, 30, 0, 0, ny), // after daylight savings starts
+	}, upcoming)
+	assert.Equal(t, 23*time.Hour, upcoming[1].Sub(upcoming[0]))
+}
+
+func TestRule_NeverOccurs(t *testing.T) {
+	// Every 4 years from 2021, February never lands on a leap year and so
+	// never has a 5th Monday
+	rule := Rule{
+		Frequency: Monthly,
+		Interval:  48,
+		Start:     timex.MustParseDate("2021-02-01"),
+		Nth:       5,
+		Weekday:   time.Monday,
+	}
+
+	_, err := rule.NextAfter(utc("2021-01-01T00:00:00Z"))
+	assert.EqualError(t, err, "no occurrences after 2021-01-01T00:00:00Z")
+
+	upcoming, err := rule.Upcoming(fixedClock(utc("2021-01-01T00:00:00Z")), 3)
+	require.NoError(t, err)
+	assert.Empty(t, upcoming)
+}
+
+func TestRule_Validate(t *testing.T) {
+	start := timex.MustParseDate("2024-01-01")
+	for _, tt := range []struct {
+		rule Rule
+		err  string
+	}{
+		{Rule{Start: start}, "invalid frequency Frequency(0)"},
+		{Rule{Frequency: Daily, Interval: -1, Start: start}, "invalid interval -1"},
+		{Rule{Frequency: Daily}, "start date is required"},
+		{Rule{Frequency: Monthly, Start: start, Nth: 6}, "invalid nth 6: must be between -5 and 5"},
+		{Rule{Frequency: Daily, Start: start, TimeOfDay: 25 * time.Hour}, "invalid time of day 25h0m0s"},
+	} {
+		t.Run(tt.err, func(t *testing.T) {
+			_, err := tt.rule.NextAfter(utc("2024-01-01T00:00:00Z"))
+			assert.EqualError(t, err, tt.err)
+
+			for range tt.rule.After(utc("2024-01-01T00:00:00Z")) {
+				assert.Fail(t, "invalid rule has occurrences")
+			}
+		})
+	}
+}
+
+func TestNthWeekday(t *testing.T) {
+	my := timex.MustParseMonthYear("2024-02")
+	for _, tt := range []struct {
+		weekday time.Weekday
+		n       int
+		want    string
+	}{
+		{time.Thursday, 1, "2024-02-01"},
+		{time.Wednesday, 1, "2024-02-07"},
+		{time.Thursday, 5, "2024-02-29"},
+		{time.Friday, 5, ""},
+		{time.Thursday, -1, "2024-02-29"},
+		{time.Friday, -1, "2024-02-23"},
+		{time.Thursday, -5, "2024-02-01"},
+		{time.Friday, -5, ""},
+		{time.Friday, 0, ""},
+	} {
+		d, ok := NthWeekday(my, tt.weekday, tt.n)
+		if tt.want == "" {
+			assert.False(t, ok, "%s %d", tt.weekday, tt.n)
+			continue
+		}
+
+		assert.True(t, ok, "%s %d", tt.weekday, tt.n)
+		assert.Equal(t, timex.MustParseDate(tt.want), d, "%s %d", tt.weekday, tt.n)
+	}
+}
@@ -14,3 +14,16 @@ func MustParseTime(layout, s string) time.Time {
 	}
 	return t
 }
+
+// A Clock provides the current time. Clocks from github.com/jonboulle/clockwork
+// satisfy this interface, so fake clocks can be used in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock is a Clock that returns the system time.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
@@ -0,0 +1,155 @@
+package timex
+
+import (
+	"encoding"
+	"fmt"
+	"strconv"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A Year is a calendar year.
+type Year int
+
+// ParseYear parses a four digit year.
+func ParseYear(s string) (Year, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || len(s) != 4 {
+		return 0, fmt.Errorf("invalid year '%s'", s)
+	}
+
+	return Year(n), nil
+}
+
+// MustParseYear parses a year, panicking if the year cannot
+// be parsed. Useful for tests.
+func MustParseYear(s string) Year {
+	y, err := ParseYear(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return y
+}
+
+// UnmarshalText unmarshalls the year from a text value.
+// Implements the TextUnmarshaler interface.
+func (y *Year) UnmarshalText(text []byte) error {
+	u, err := ParseYear(string(text))
+	if err != nil {
+		return err
+	}
+
+	*y = u
+	return nil
+}
+
+// MarshalText marshals the year as a text value.
+// Implements the TextMarshaler interface.
+func (y Year) MarshalText() ([]byte, error) {
+	return []byte(y.String()), nil
+}
+
+// UnmarshalYAML unmarshals the year from a YAML scalar.
+func (y *Year) UnmarshalYAML(n *yaml.Node) error {
+	var s string
+	if err := n.Decode(&s); err != nil {
+		return err
+	}
+
+	return y.UnmarshalText([]byte(s))
+}
+
+// MarshalYAML marshals the year as a YAML string.
+func (y Year) MarshalYAML() (any, error) {
+	return y.String(), nil
+}
+
+// String returns a string format of the year.
+func (y Year) String() string {
+	return fmt.Sprintf("%04d", int(y))
+}
+
+// YearStart returns the date that is the start of the year.
+func (y Year) YearStart() Date {
+	return Date{Day: 1, Month: time.January, Year: int(y)}
+}
+
+// YearEnd returns the date that is the end of the year.
+func (y Year) YearEnd() Date {
+	return Date{Day: 31, Month: time.December, Year: int(y)}
+}
+
+// FirstQuarter returns the first quarter of the year.
+func (y Year) FirstQuarter() Quarter {
+	return Quarter{Quarter: 1, Year: int(y)}
+}
+
+// LastQuarter returns the last quarter of the year.
+func (y Year) LastQuarter() Quarter {
+	return Quarter{Quarter: 4, Year: int(y)}
+}
+
+// AddYears returns the year numYears out.
+func (y Year) AddYears(numYears int) Year {
+	return y + Year(numYears)
+}
+
+// PriorYear returns the prior year.
+func (y Year) PriorYear() Year {
+	return y.AddYears(-1)
+}
+
+// NextYear returns the next year.
+func (y Year) NextYear() Year {
+	return y.AddYears(1)
+}
+
+// IsZero returns true if the year is not set.
+func (y Year) IsZero() bool {
+	return y == 0
+}
+
+// After returns true if this year is after another.
+func (y Year) After(other Year) bool {
+	return y > other
+}
+
+// Before returns true if this year is before another.
+func (y Year) Before(other Year) bool {
+	return y < other
+}
+
+// Equal returns true if this year is equal to another.
+func (y Year) Equal(other Year) bool {
+	return y == other
+}
+
+// CompareTo compares two years. Returns:
+//
+//	-1 if this Year is earlier than the provided Year
+//	1 if this Year is later than the provided Year
+//	0 if this Year is the same as the provided Year
+func (y Year) CompareTo(other Year) int {
+	switch {
+	case y > other:
+		return 1
+	case y < other:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// Less returns true if the Year is earlier than the provided Year.
+func (y Year) Less(other Year) bool {
+	return y < other
+}
+
+var (
+	_ encoding.TextUnmarshaler = new(Year)
+	_ encoding.TextMarshaler   = Year(0)
+	_ yaml.Unmarshaler         = new(Year)
+	_ yaml.Marshaler           = Year(0)
+)
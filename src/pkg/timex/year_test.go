@@ -0,0 +1,65 @@
+package timex
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestYear(t *testing.T) {
+	y, err := ParseYear("2024")
+	require.NoError(t, err)
+	assert.Equal(t, Year(2024), y)
+	assert.Equal(t, "2024", y.String())
+	assert.Equal(t, MustParseDate("2024-01-01"), y.YearStart())
+	assert.Equal(t, MustParseDate("2024-12-31"), y.YearEnd())
+	assert.Equal(t, MustParseQuarter("2024-Q1"), y.FirstQuarter())
+	assert.Equal(t, MustParseQuarter("2024-Q4"), y.LastQuarter())
+	assert.Equal(t, Year(2025), y.NextYear())
+	assert.Equal(t, Year(2023), y.PriorYear())
+	assert.Equal(t, Year(2014), y.AddYears(-10))
+
+	for _, s := range []string{"24", "20245", "abcd", ""} {
+		_, err := ParseYear(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestYear_CompareTo(t *testing.T) {
+	baseline := Year(2024)
+	for _, tt := range []struct {
+		other Year
+		want  int
+	}{
+		{2023, 1},
+		{2025, -1},
+		{2024, 0},
+	} {
+		assert.Equal(t, tt.want, baseline.CompareTo(tt.other))
+		assert.Equal(t, tt.want < 0, baseline.Less(tt.other))
+		assert.Equal(t, tt.want < 0, baseline.Before(tt.other))
+		assert.Equal(t, tt.want > 0, baseline.After(tt.other))
+		assert.Equal(t, tt.want == 0, baseline.Equal(tt.other))
+	}
+}
+
+func TestYearMarshalling(t *testing.T) {
+	type Embedded struct {
+		When Year `json:"when" yaml:"when"`
+	}
+
+	b, err := json.Marshal(Embedded{When: 2024})
+	require.NoError(t, err)
+	assert.Equal(t, `{"when":"2024"}`, string(b))
+
+	var em Embedded
+	require.NoError(t, json.Unmarshal(b, &em))
+	assert.Equal(t, Embedded{When: 2024}, em)
+
+	em = Embedded{}
+	require.NoError(t, yaml.Unmarshal([]byte("when: 2024"), &em))
+	assert.Equal(t, Embedded{When: 2024}, em)
+}
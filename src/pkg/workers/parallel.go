@@ -0,0 +1,66 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/multierr"
+)
+
+// ForEach calls fn for each item, running at most concurrency calls at once.
+// Every item is processed even if some fail, and the errors are combined in
+// item order. If the context is canceled, no further items are started and
+// the context's error is included in the result.
+func ForEach[T any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, item T) error) error {
+	_, err := Map(ctx, items, concurrency, func(ctx context.Context, item T) (struct{}, error) {
+		return struct{}{}, fn(ctx, item)
+	})
+	return err
+}
+
+// Map calls fn for each item, running at most concurrency calls at once, and
+// returns the results in the same order as the items. Every item is processed
+// even if some fail, and the errors are combined in item order; the results
+// of failed items are left as zero values. If the context is canceled, no
+// further items are started and the context's error is included in the result.
+func Map[T, R any](ctx context.Context, items []T, concurrency int, fn func(ctx context.Context, item T) (R, error)) ([]R, error) {
+	var (
+		results = make([]R, len(items))
+		errs    = make([]error, len(items))
+		next    atomic.Int64
+		wg      sync.WaitGroup
+	)
+
+	concurrency = max(1, min(concurrency, len(items)))
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(items) || ctx.Err() != nil {
+					return
+				}
+
+				results[i], errs[i] = fn(ctx, items[i])
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	var err error
+	for i, itemErr := range errs {
+		if itemErr != nil {
+			err = multierr.Append(err, fmt.Errorf("item %d: %w", i, itemErr))
+		}
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = multierr.Append(err, ctxErr)
+	}
+
+	return results, err
+}
@@ -0,0 +1,96 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/multierr"
+)
+
+func TestMap(t *testing.T) {
+	items := make([]int, 50)
+	for i := range items {
+		items[i] = i
+	}
+
+	var running, maxRunning atomic.Int32
+	results, err := Map(context.Background(), items, 4, func(_ context.Context, n int) (string, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			prev := maxRunning.Load()
+			if cur <= prev || maxRunning.CompareAndSwap(prev, cur) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return strconv.Itoa(n), nil
+	})
+
+	require.NoError(t, err)
+	for i, v := range results {
+		assert.Equal(t, strconv.Itoa(i), v)
+	}
+	assert.LessOrEqual(t, maxRunning.Load(), int32(4))
+}
+
+func TestMap_Errors(t *testing.T) {
+	errBad := errors.New("bad item")
+	results, err := Map(context.Background(), []int{1, 2, 3, 4}, 2, func(_ context.Context, n int) (int, error) {
+		if n%2 == 0 {
+			return 0, errBad
+		}
+		return n * 10, nil
+	})
+
+	assert.Equal(t, []int{10, 0, 30, 0}, results)
+	require.ErrorIs(t, err, errBad)
+
+	errs := multierr.Errors(err)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "item 1: bad item")
+	assert.EqualError(t, errs[1], "item 3: bad item")
+}
+
+func TestMap_Empty(t *testing.T) {
+	results, err := Map(context.Background(), []int{}, 0, func(_ context.Context, n int) (int, error) {
+		return n, nil
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestForEach(t *testing.T) {
+	var sum atomic.Int64
+	err := ForEach(context.Background(), []int64{1, 2, 3, 4}, 0, func(_ context.Context, n int64) error {
+		sum.Add(n)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), sum.Load())
+}
+
+func TestForEach_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var processed atomic.Int32
+	err := ForEach(ctx, make([]int, 100), 1, func(_ context.Context, _ int) error {
+		if processed.Add(1) == 5 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(5), processed.Load())
+}
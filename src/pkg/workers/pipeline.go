@@ -0,0 +1,172 @@
+package workers
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"slices"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// A Pipeline runs a series of stages connected by buffered channels, with
+// each stage processing items across its own number of goroutines. Pipelines
+// are built by starting with From, adding stages with Then, and ending with
+// Sink, then calling Wait for the pipeline to finish.
+//
+// Items that fail in a stage are dropped, and the error is recorded against the
+// stage, so one bad item does not stop the pipeline. Canceling the pipeline's
+// context stops all stages; every channel send also watches the context, so
+// shutting down never deadlocks.
+type Pipeline struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mut    sync.Mutex
+	stages []string
+	errs   map[string][]error
+}
+
+// A Stage is the output of one step of a Pipeline, which feeds the next step.
+type Stage[T any] struct {
+	p   *Pipeline
+	out <-chan T
+}
+
+// NewPipeline creates a new Pipeline that runs until the context is canceled.
+func NewPipeline(ctx context.Context) *Pipeline {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Pipeline{
+		ctx:    ctx,
+		cancel: cancel,
+		errs:   make(map[string][]error),
+	}
+}
+
+// From starts a Pipeline with the items produced by a sequence, sending them
+// to the next stage through a channel with the given buffer size.
+func From[T any](p *Pipeline, buffer int, seq iter.Seq[T]) *Stage[T] {
+	out := make(chan T, buffer)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer close(out)
+		for v := range seq {
+			select {
+			case out <- v:
+			case <-p.ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &Stage[T]{p: p, out: out}
+}
+
+// Then adds a stage that converts each item with fn, running concurrency
+// goroutines and sending the results to the next stage through a channel
+// with the given buffer size. Results are not guaranteed to be in order.
+func Then[T, R any](in *Stage[T], name string, concurrency, buffer int, fn func(ctx context.Context, item T) (R, error)) *Stage[R] {
+	p := in.p
+	out := make(chan R, buffer)
+
+	done := run(p, in.out, concurrency, func(item T) bool {
+		r, err := fn(p.ctx, item)
+		if err != nil {
+			p.addError(name, err)
+			return true
+		}
+
+		select {
+		case out <- r:
+			return true
+		case <-p.ctx.Done():
+			return false
+		}
+	})
+
+	go func() {
+		<-done
+		close(out)
+	}()
+
+	return &Stage[R]{p: p, out: out}
+}
+
+// Sink ends a Pipeline with a stage that consumes each item with fn,
+// running concurrency goroutines.
+func Sink[T any](in *Stage[T], name string, concurrency int, fn func(ctx context.Context, item T) error) {
+	p := in.p
+	run(p, in.out, concurrency, func(item T) bool {
+		if err := fn(p.ctx, item); err != nil {
+			p.addError(name, err)
+		}
+		return true
+	})
+}
+
+// run starts the goroutines for a stage, returning a channel that is
+// closed once they have all finished.
+func run[T any](p *Pipeline, in <-chan T, concurrency int, process func(item T) bool) <-chan struct{} {
+	var stage sync.WaitGroup
+	concurrency = max(1, concurrency)
+	stage.Add(concurrency)
+	p.wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go func() {
+			defer p.wg.Done()
+			defer stage.Done()
+			for item := range in {
+				if p.ctx.Err() != nil || !process(item) {
+					return
+				}
+			}
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		stage.Wait()
+		close(done)
+	}()
+
+	return done
+}
+
+// Wait waits for all stages of the pipeline to finish, returning the combined
+// errors from all stages, along with the context's error if it was canceled.
+func (p *Pipeline) Wait() error {
+	p.wg.Wait()
+	ctxErr := p.ctx.Err()
+	p.cancel()
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	var err error
+	for _, stage := range p.stages {
+		for _, stageErr := range p.errs[stage] {
+			err = multierr.Append(err, fmt.Errorf("stage '%s': %w", stage, stageErr))
+		}
+	}
+
+	return multierr.Append(err, ctxErr)
+}
+
+// Errors returns the errors recorded by a stage.
+func (p *Pipeline) Errors(stage string) []error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return slices.Clone(p.errs[stage])
+}
+
+func (p *Pipeline) addError(stage string, err error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if _, ok := p.errs[stage]; !ok {
+		p.stages = append(p.stages, stage)
+	}
+	p.errs[stage] = append(p.errs[stage], err)
+}
@@ -0,0 +1,126 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	p := NewPipeline(context.Background())
+
+	numbers := From(p, 10, slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+	squares := Then(numbers, "square", 3, 5, func(_ context.Context, n int) (int, error) {
+		return n * n, nil
+	})
+	labels := Then(squares, "label", 2, 5, func(_ context.Context, n int) (string, error) {
+		return strconv.Itoa(n), nil
+	})
+
+	var (
+		mut     sync.Mutex
+		results []string
+	)
+	Sink(labels, "collect", 1, func(_ context.Context, s string) error {
+		mut.Lock()
+		defer mut.Unlock()
+		results = append(results, s)
+		return nil
+	})
+
+	require.NoError(t, p.Wait())
+	assert.ElementsMatch(t, []string{"1", "4", "9", "16", "25", "36", "49", "64", "81", "100"}, results)
+}
+
+func TestPipeline_StageErrors(t *testing.T) {
+	errOdd := errors.New("odd")
+	errBig := errors.New("too big")
+	p := NewPipeline(context.Background())
+
+	evens := Then(From(p, 0, slices.Values([]int{1, 2, 3, 4, 5, 6})), "evens", 2, 0,
+		func(_ context.Context, n int) (int, error) {
+			if n%2 == 1 {
+				return 0, errOdd
+			}
+			return n, nil
+		})
+
+	var (
+		mut   sync.Mutex
+		total int
+	)
+	Sink(evens, "sum", 2, func(_ context.Context, n int) error {
+		if n > 4 {
+			return errBig
+		}
+
+		mut.Lock()
+		defer mut.Unlock()
+		total += n
+		return nil
+	})
+
+	err := p.Wait()
+	require.ErrorIs(t, err, errOdd)
+	require.ErrorIs(t, err, errBig)
+	assert.ErrorContains(t, err, "stage 'evens': odd")
+	assert.ErrorContains(t, err, "stage 'sum': too big")
+
+	assert.Len(t, p.Errors("evens"), 3)
+	assert.Len(t, p.Errors("sum"), 1)
+	assert.Empty(t, p.Errors("missing"))
+	assert.Equal(t, 6, total)
+}
+
+func TestPipeline_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewPipeline(ctx)
+
+	// An endless source, with a slow sink that cancels partway through
+	endless := func(yield func(int) bool) {
+		for i := 0; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	doubled := Then(From(p, 1, endless), "double", 4, 1, func(_ context.Context, n int) (int, error) {
+		return n * 2, nil
+	})
+
+	var (
+		mut  sync.Mutex
+		seen int
+	)
+	Sink(doubled, "slow", 1, func(_ context.Context, _ int) error {
+		mut.Lock()
+		defer mut.Unlock()
+		if seen++; seen == 10 {
+			cancel()
+		}
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+
+	done := make(chan error)
+	go func() {
+		done <- p.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "pipeline did not shut down within 5s")
+	}
+}
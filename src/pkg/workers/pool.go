@@ -0,0 +1,147 @@
+// Package workers contains utilities for processing work concurrently
+// across a bounded number of goroutines.
+package workers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrPoolClosed is returned when submitting work to a Pool that has been closed.
+var ErrPoolClosed = errors.New("worker pool closed")
+
+// A Result is the outcome of processing a single item.
+type Result[R any] struct {
+	Value R
+	Err   error
+}
+
+// A Pool processes items across a fixed number of worker goroutines. Panics
+// while processing an item are recovered and returned as errors, so a bad item
+// does not take down the process.
+type Pool[T, R any] struct {
+	fn        func(ctx context.Context, item T) (R, error)
+	tasks     chan task[T, R]
+	mut       sync.RWMutex
+	closed    bool
+	workers   sync.WaitGroup
+	closing   chan struct{}
+	drained   chan struct{}
+	closeOnce sync.Once
+}
+
+type task[T, R any] struct {
+	ctx    context.Context
+	item   T
+	result chan Result[R]
+}
+
+// NewPool starts a Pool that processes items with fn across the given number
+// of workers. Callers must call Close to stop the workers.
+func NewPool[T, R any](workers int, fn func(ctx context.Context, item T) (R, error)) *Pool[T, R] {
+	workers = max(workers, 1)
+	p := &Pool[T, R]{
+		fn:      fn,
+		tasks:   make(chan task[T, R]),
+		closing: make(chan struct{}),
+		drained: make(chan struct{}),
+	}
+
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+// Submit processes an item, blocking until it has been processed. Returns an
+// error if the item could not be processed, the context is canceled before
+// the item is processed, or the pool is closed.
+func (p *Pool[T, R]) Submit(ctx context.Context, item T) (R, error) {
+	select {
+	case r := <-p.SubmitAsync(ctx, item):
+		return r.Value, r.Err
+	case <-ctx.Done():
+		var zero R
+		return zero, ctx.Err()
+	}
+}
+
+// SubmitAsync queues an item for processing, returning a channel that
+// receives the result once the item has been processed. Blocks until a worker
+// is available to take the item, the context is canceled, or the pool is
+// closed; in the latter cases the channel receives the error.
+func (p *Pool[T, R]) SubmitAsync(ctx context.Context, item T) <-chan Result[R] {
+	result := make(chan Result[R], 1)
+
+	p.mut.RLock()
+	defer p.mut.RUnlock()
+
+	if p.closed {
+		result <- Result[R]{Err: ErrPoolClosed}
+		return result
+	}
+
+	select {
+	case p.tasks <- task[T, R]{ctx: ctx, item: item, result: result}:
+	case <-ctx.Done():
+		result <- Result[R]{Err: ctx.Err()}
+	case <-p.closing:
+		result <- Result[R]{Err: ErrPoolClosed}
+	}
+
+	return result
+}
+
+// Close stops accepting new items and waits for the workers to finish the
+// items already submitted. Submitters blocked waiting for a worker receive
+// ErrPoolClosed. Returns an error if the context is canceled before the
+// workers have drained.
+func (p *Pool[T, R]) Close(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		// Wake blocked submitters first, so they release the lock
+		close(p.closing)
+
+		go func() {
+			p.mut.Lock()
+			p.closed = true
+			close(p.tasks)
+			p.mut.Unlock()
+
+			p.workers.Wait()
+			close(p.drained)
+		}()
+	})
+
+	select {
+	case <-p.drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (p *Pool[T, R]) work() {
+	defer p.workers.Done()
+	for t := range p.tasks {
+		t.result <- p.process(t)
+	}
+}
+
+func (p *Pool[T, R]) process(t task[T, R]) (result Result[R]) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Result[R]{Err: fmt.Errorf("panic processing item: %v", r)}
+		}
+	}()
+
+	if err := t.ctx.Err(); err != nil {
+		return Result[R]{Err: err}
+	}
+
+	v, err := p.fn(t.ctx, t.item)
+	return Result[R]{Value: v, Err: err}
+}
@@ -0,0 +1,170 @@
+package workers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(t *testing.T) {
+	var running, maxRunning atomic.Int32
+	p := NewPool(3, func(_ context.Context, n int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			prev := maxRunning.Load()
+			if cur <= prev || maxRunning.CompareAndSwap(prev, cur) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+		return n * n, nil
+	})
+
+	var wg sync.WaitGroup
+	results := make([]int, 20)
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := p.Submit(context.Background(), i)
+			assert.NoError(t, err)
+			results[i] = v
+		}()
+	}
+
+	wg.Wait()
+	require.NoError(t, p.Close(context.Background()))
+
+	for i, v := range results {
+		assert.Equal(t, i*i, v)
+	}
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestPool_Errors(t *testing.T) {
+	errOdd := errors.New("odd")
+	p := NewPool(2, func(_ context.Context, n int) (string, error) {
+		switch {
+		case n < 0:
+			panic("negative")
+		case n%2 == 1:
+			return "", errOdd
+		default:
+			return "even", nil
+		}
+	})
+
+	v, err := p.Submit(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "even", v)
+
+	_, err = p.Submit(context.Background(), 3)
+	assert.ErrorIs(t, err, errOdd)
+
+	// Panics are recovered, and the pool keeps working
+	_, err = p.Submit(context.Background(), -1)
+	assert.ErrorContains(t, err, "panic processing item: negative")
+
+	r := <-p.SubmitAsync(context.Background(), 4)
+	require.NoError(t, r.Err)
+	assert.Equal(t, "even", r.Value)
+
+	require.NoError(t, p.Close(context.Background()))
+	require.NoError(t, p.Close(context.Background()))
+
+	_, err = p.Submit(context.Background(), 2)
+	assert.ErrorIs(t, err, ErrPoolClosed)
+}
+
+func TestPool_Drain(t *testing.T) {
+	release := make(chan struct{})
+	var processed atomic.Int32
+	p := NewPool(1, func(_ context.Context, _ int) (int, error) {
+		<-release
+		processed.Add(1)
+		return 0, nil
+	})
+
+	results := []<-chan Result[int]{p.SubmitAsync(context.Background(), 1)}
+
+	// Close times out while the submitted item is still blocked
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	assert.ErrorIs(t, p.Close(ctx), context.DeadlineExceeded)
+
+	// Once released, the item completes and the pool drains
+	close(release)
+	require.NoError(t, p.Close(context.Background()))
+	assert.NoError(t, (<-results[0]).Err)
+	assert.Equal(t, int32(1), processed.Load())
+}
+
+func TestPool_ContextCanceled(t *testing.T) {
+	block := make(chan struct{})
+	p := NewPool(1, func(_ context.Context, _ int) (int, error) {
+		<-block
+		return 0, nil
+	})
+
+	// Occupy the only worker
+	first := p.SubmitAsync(context.Background(), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	_, err := p.Submit(ctx, 2)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(block)
+	assert.NoError(t, (<-first).Err)
+	require.NoError(t, p.Close(context.Background()))
+}
+
+func TestPool_CloseWithBlockedSubmitter(t *testing.T) {
+	release := make(chan struct{})
+	p := NewPool(1, func(_ context.Context, _ int) (int, error) {
+		<-release
+		return 0, nil
+	})
+
+	// Occupy the only worker, then block a second submitter waiting for it
+	first := p.SubmitAsync(context.Background(), 1)
+	blocked := make(chan (<-chan Result[int]))
+	go func() {
+		blocked <- p.SubmitAsync(context.Background(), 2)
+	}()
+	time.Sleep(time.Millisecond * 10)
+
+	closed := make(chan error)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+		closed <- p.Close(ctx)
+	}()
+
+	select {
+	case err := <-closed:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "close did not honor its deadline within 5s")
+	}
+
+	select {
+	case result := <-blocked:
+		assert.ErrorIs(t, (<-result).Err, ErrPoolClosed)
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "blocked submitter not released within 5s")
+	}
+
+	close(release)
+	assert.NoError(t, (<-first).Err)
+	require.NoError(t, p.Close(context.Background()))
+}